@@ -0,0 +1,175 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/GeekWorkCode/plane-api-go/client"
+	"github.com/GeekWorkCode/plane-api-go/models"
+)
+
+// ActivitiesService handles communication with the issue activity related endpoints
+type ActivitiesService struct {
+	client *client.Client
+}
+
+// NewActivitiesService creates a new activities service
+func NewActivitiesService(client *client.Client) *ActivitiesService {
+	return &ActivitiesService{
+		client: client,
+	}
+}
+
+// List returns the activity feed of an issue
+func (s *ActivitiesService) List(workspaceSlug string, projectID string, issueID string) ([]models.Activity, error) {
+	return s.ListWithContext(context.Background(), workspaceSlug, projectID, issueID)
+}
+
+// ListWithContext returns the activity feed of an issue, following pagination until every page is fetched
+func (s *ActivitiesService) ListWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string) ([]models.Activity, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/activities/", workspaceSlug, projectID, issueID)
+	results, err := listAll[models.Activity](ctx, s.client, path, nil)
+	return results, err
+}
+
+// ListPage returns a single page of the activity feed of an issue
+func (s *ActivitiesService) ListPage(workspaceSlug string, projectID string, issueID string, opts *ListOptions) (*models.PaginatedResponse[models.Activity], error) {
+	return s.ListPageWithContext(context.Background(), workspaceSlug, projectID, issueID, opts)
+}
+
+// ListPageWithContext returns a single page of the activity feed of an issue
+func (s *ActivitiesService) ListPageWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, opts *ListOptions) (*models.PaginatedResponse[models.Activity], error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/activities/", workspaceSlug, projectID, issueID)
+	page, err := listPage[models.Activity](ctx, s.client, path, opts.values())
+	return page, err
+}
+
+// Iterate returns an iterator over the activity feed of an issue, starting at opts.Cursor
+func (s *ActivitiesService) Iterate(ctx context.Context, workspaceSlug string, projectID string, issueID string, opts *ListOptions) *Iterator[models.Activity] {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/activities/", workspaceSlug, projectID, issueID)
+	return newListIterator[models.Activity](ctx, s.client, path, opts.values())
+}
+
+// Get returns an activity by its ID
+func (s *ActivitiesService) Get(workspaceSlug string, projectID string, issueID string, activityID string) (*models.Activity, error) {
+	return s.GetWithContext(context.Background(), workspaceSlug, projectID, issueID, activityID)
+}
+
+// GetWithContext returns an activity by its ID
+func (s *ActivitiesService) GetWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, activityID string) (*models.Activity, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/activities/%s/", workspaceSlug, projectID, issueID, activityID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	activity := new(models.Activity)
+	_, err = s.client.Do(req, activity)
+	return activity, err
+}
+
+// StatePeriod is a span of time an issue spent in a state
+type StatePeriod struct {
+	StateID   string
+	StateName string
+	Start     time.Time
+	End       time.Time // zero while the issue is still in the state
+}
+
+// StateDuration is the total time an issue spent in a state
+// 问题在某个状态中停留的总时间
+type StateDuration struct {
+	StateID   string
+	StateName string
+	Duration  time.Duration
+	Visits    int // number of times the issue entered the state
+}
+
+// StatePeriods reconstructs the states an issue went through from its activity feed.
+// The first period starts when the issue was created; the last one is open.
+func StatePeriods(issue *models.Issue, activities []models.Activity) []StatePeriod {
+	changes := make([]models.Activity, 0, len(activities))
+	for _, activity := range activities {
+		if activity.Field == "state" && activity.Verb == "updated" {
+			changes = append(changes, activity)
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].CreatedAt.Before(changes[j].CreatedAt)
+	})
+
+	current := StatePeriod{StateID: issue.State, Start: issue.CreatedAt}
+	if issue.StateDetail != nil {
+		current.StateName = issue.StateDetail.Name
+	}
+	if len(changes) > 0 {
+		current.StateID, current.StateName = changes[0].OldIdentifier, changes[0].OldValue
+	}
+
+	periods := make([]StatePeriod, 0, len(changes)+1)
+	for _, change := range changes {
+		current.End = change.CreatedAt
+		periods = append(periods, current)
+		current = StatePeriod{StateID: change.NewIdentifier, StateName: change.NewValue, Start: change.CreatedAt}
+	}
+	return append(periods, current)
+}
+
+// ComputeTimeInState sums the time an issue spent in each state, in the order the
+// states were first entered. The time in the current state is counted up to now.
+func ComputeTimeInState(issue *models.Issue, activities []models.Activity, now time.Time) []StateDuration {
+	var durations []StateDuration
+	index := map[string]int{}
+	for _, period := range StatePeriods(issue, activities) {
+		end := period.End
+		if end.IsZero() {
+			end = now
+		}
+		i, ok := index[period.StateID]
+		if !ok {
+			i = len(durations)
+			index[period.StateID] = i
+			durations = append(durations, StateDuration{StateID: period.StateID, StateName: period.StateName})
+		}
+		durations[i].Duration += end.Sub(period.Start)
+		durations[i].Visits++
+	}
+	return durations
+}
+
+// TimeInState returns the time an issue spent in each state, up to now
+func (s *ActivitiesService) TimeInState(workspaceSlug string, projectID string, issueID string) ([]StateDuration, error) {
+	return s.TimeInStateWithContext(context.Background(), workspaceSlug, projectID, issueID)
+}
+
+// TimeInStateWithContext returns the time an issue spent in each state, up to now.
+// State names missing from the history are filled in from the current project states.
+func (s *ActivitiesService) TimeInStateWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string) ([]StateDuration, error) {
+	issue, err := NewIssuesService(s.client).GetWithContext(ctx, workspaceSlug, projectID, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("获取问题失败: %w", err)
+	}
+	activities, err := s.ListWithContext(ctx, workspaceSlug, projectID, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("获取活动列表失败: %w", err)
+	}
+	states, err := NewStatesService(s.client).ListWithContext(ctx, workspaceSlug, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("获取状态列表失败: %w", err)
+	}
+
+	names := map[string]string{}
+	for _, state := range states {
+		names[state.ID] = state.Name
+	}
+	durations := ComputeTimeInState(issue, activities, time.Now())
+	for i := range durations {
+		if durations[i].StateName == "" {
+			durations[i].StateName = names[durations[i].StateID]
+		}
+	}
+	return durations, nil
+}
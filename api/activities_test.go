@@ -0,0 +1,143 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GeekWorkCode/plane-api-go/client"
+	"github.com/GeekWorkCode/plane-api-go/models"
+	"github.com/GeekWorkCode/plane-api-go/planetest"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestActivitiesService tests all methods of the ActivitiesService
+// 测试 ActivitiesService 的所有方法
+func TestActivitiesService(t *testing.T) {
+	// Use the live API if configured, the fake server otherwise
+	env := newTestEnv(t)
+
+	workspaceSlug := env.workspaceSlug
+	projectID := env.projectID
+	issueID := env.issueID
+
+	if workspaceSlug == "" || projectID == "" || issueID == "" {
+		t.Skip("Required environment variables not set")
+	}
+
+	s := NewActivitiesService(env.client)
+
+	var activityID string
+
+	// Test List method
+	// 测试 List 方法
+	t.Run("List", func(t *testing.T) {
+		activities, err := s.List(workspaceSlug, projectID, issueID)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, activities)
+		if len(activities) > 0 {
+			activityID = activities[0].ID
+		}
+	})
+
+	// Test Get method
+	// 测试 Get 方法
+	t.Run("Get", func(t *testing.T) {
+		if activityID == "" {
+			t.Skip("No activity available for testing")
+		}
+		activity, err := s.Get(workspaceSlug, projectID, issueID, activityID)
+		assert.NoError(t, err)
+		assert.Equal(t, activityID, activity.ID)
+		assert.Equal(t, issueID, activity.Issue)
+	})
+
+	// Test TimeInState method
+	// 测试 TimeInState 方法
+	t.Run("TimeInState", func(t *testing.T) {
+		durations, err := s.TimeInState(workspaceSlug, projectID, issueID)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, durations)
+	})
+}
+
+// TestComputeTimeInState tests computing time-in-state durations from an issue history
+// 测试根据问题历史计算各状态的停留时间
+func TestComputeTimeInState(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	change := func(after time.Duration, from, to string) models.Activity {
+		return models.Activity{
+			Verb: "updated", Field: "state",
+			OldIdentifier: from, OldValue: from + "-name",
+			NewIdentifier: to, NewValue: to + "-name",
+			CreatedAt: start.Add(after),
+		}
+	}
+	issue := &models.Issue{State: "done", CreatedAt: start}
+	activities := []models.Activity{
+		change(5*time.Hour, "progress", "todo"),
+		{Verb: "updated", Field: "priority", CreatedAt: start.Add(time.Hour)},
+		change(2*time.Hour, "todo", "progress"), // 历史可以是乱序的
+		change(8*time.Hour, "todo", "done"),
+	}
+
+	periods := StatePeriods(issue, activities)
+	if assert.Len(t, periods, 4) {
+		assert.Equal(t, StatePeriod{StateID: "todo", StateName: "todo-name", Start: start, End: start.Add(2 * time.Hour)}, periods[0])
+		assert.True(t, periods[3].End.IsZero())
+	}
+
+	durations := ComputeTimeInState(issue, activities, start.Add(24*time.Hour))
+	assert.Equal(t, []StateDuration{
+		{StateID: "todo", StateName: "todo-name", Duration: 5 * time.Hour, Visits: 2},
+		{StateID: "progress", StateName: "progress-name", Duration: 3 * time.Hour, Visits: 1},
+		{StateID: "done", StateName: "done-name", Duration: 16 * time.Hour, Visits: 1},
+	}, durations)
+
+	// 没有状态变化时, 问题一直处于当前状态
+	durations = ComputeTimeInState(issue, nil, start.Add(time.Hour))
+	assert.Equal(t, []StateDuration{{StateID: "done", Duration: time.Hour, Visits: 1}}, durations)
+}
+
+// TestActivitiesServiceTimeInState tests the time-in-state report of an issue updated over time
+// 测试随时间更新的问题的状态停留时间报告
+func TestActivitiesServiceTimeInState(t *testing.T) {
+	srv := planetest.NewServer()
+	defer srv.Close()
+	fx := srv.Seed()
+
+	now := time.Now().Add(-48 * time.Hour)
+	srv.SetClock(func() time.Time { return now })
+
+	c := client.NewClient(srv.APIKey)
+	c.SetBaseURL(srv.BaseURL())
+	issues := NewIssuesService(c)
+
+	issue, err := issues.Create(fx.WorkspaceSlug, fx.ProjectID, &IssueCreateRequest{Name: "SLA issue", StateName: "Todo"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	for _, step := range []struct {
+		after time.Duration
+		state string
+	}{{3 * time.Hour, "In Progress"}, {2 * time.Hour, "Done"}} {
+		now = now.Add(step.after)
+		_, err := issues.Update(fx.WorkspaceSlug, fx.ProjectID, issue.ID, &IssueUpdateRequest{StateName: step.state})
+		assert.NoError(t, err)
+	}
+
+	durations, err := NewActivitiesService(c).TimeInState(fx.WorkspaceSlug, fx.ProjectID, issue.ID)
+	if !assert.NoError(t, err) || !assert.Len(t, durations, 3) {
+		return
+	}
+	assert.Equal(t, "Todo", durations[0].StateName)
+	assert.Equal(t, 3*time.Hour, durations[0].Duration)
+	assert.Equal(t, "In Progress", durations[1].StateName)
+	assert.Equal(t, 2*time.Hour, durations[1].Duration)
+	assert.Equal(t, "Done", durations[2].StateName)
+	assert.Greater(t, durations[2].Duration, 40*time.Hour)
+
+	done, err := issues.Get(fx.WorkspaceSlug, fx.ProjectID, issue.ID)
+	if assert.NoError(t, err) {
+		assert.NotNil(t, done.CompletedAt)
+	}
+}
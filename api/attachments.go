@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -34,8 +35,13 @@ type UploadCredentialsRequest struct {
 
 // List returns all attachments for an issue
 func (s *AttachmentsService) List(workspaceSlug string, projectID string, issueID string) ([]models.Attachment, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/issues/%s/issue-attachments/", workspaceSlug, projectID, issueID)
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	return s.ListWithContext(context.Background(), workspaceSlug, projectID, issueID)
+}
+
+// ListWithContext returns all attachments for an issue
+func (s *AttachmentsService) ListWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string) ([]models.Attachment, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/issue-attachments/", workspaceSlug, projectID, issueID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -49,9 +55,34 @@ func (s *AttachmentsService) List(workspaceSlug string, projectID string, issueI
 	return attachments, nil
 }
 
+// Download writes the content of an uploaded attachment to w
+func (s *AttachmentsService) Download(workspaceSlug string, projectID string, issueID string, attachmentID string, w io.Writer) error {
+	return s.DownloadWithContext(context.Background(), workspaceSlug, projectID, issueID, attachmentID, w)
+}
+
+// DownloadWithContext writes the content of an uploaded attachment to w. Plane
+// redirects to the storage URL of the file, which is requested without the API key.
+func (s *AttachmentsService) DownloadWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, attachmentID string, w io.Writer) error {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/issue-attachments/%s/", workspaceSlug, projectID, issueID, attachmentID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.client.Do(req, w); err != nil {
+		return fmt.Errorf("下载附件失败: %w", err)
+	}
+	return nil
+}
+
 // GetUploadCredentials gets credentials to upload a file directly to cloud storage
 func (s *AttachmentsService) GetUploadCredentials(workspaceSlug string, projectID string, issueID string, filename string, fileType string, fileSize int64) (*models.UploadCredentials, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/issues/%s/issue-attachments/get-upload-url/", workspaceSlug, projectID, issueID)
+	return s.GetUploadCredentialsWithContext(context.Background(), workspaceSlug, projectID, issueID, filename, fileType, fileSize)
+}
+
+// GetUploadCredentialsWithContext gets credentials to upload a file directly to cloud storage
+func (s *AttachmentsService) GetUploadCredentialsWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, filename string, fileType string, fileSize int64) (*models.UploadCredentials, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/issue-attachments/get-upload-url/", workspaceSlug, projectID, issueID)
 
 	requestBody := &UploadCredentialsRequest{
 		Name: filename,
@@ -59,7 +90,7 @@ func (s *AttachmentsService) GetUploadCredentials(workspaceSlug string, projectI
 		Size: fileSize,
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, path, requestBody)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, path, requestBody)
 	if err != nil {
 		return nil, err
 	}
@@ -71,8 +102,13 @@ func (s *AttachmentsService) GetUploadCredentials(workspaceSlug string, projectI
 
 // CompleteUpload completes the upload process by notifying the API that the file has been uploaded
 func (s *AttachmentsService) CompleteUpload(workspaceSlug string, projectID string, issueID string, assetID string) (*models.Attachment, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/issues/%s/issue-attachments/%s/", workspaceSlug, projectID, issueID, assetID)
-	req, err := s.client.NewRequest(http.MethodPatch, path, nil)
+	return s.CompleteUploadWithContext(context.Background(), workspaceSlug, projectID, issueID, assetID)
+}
+
+// CompleteUploadWithContext completes the upload process by notifying the API that the file has been uploaded
+func (s *AttachmentsService) CompleteUploadWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, assetID string) (*models.Attachment, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/issue-attachments/%s/", workspaceSlug, projectID, issueID, assetID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPatch, path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -84,12 +120,22 @@ func (s *AttachmentsService) CompleteUpload(workspaceSlug string, projectID stri
 
 // UploadFile uploads a file to S3 using the provided credentials
 func (s *AttachmentsService) UploadFile(uploadURL string, fields map[string]string, filePath string) error {
+	return s.UploadFileWithContext(context.Background(), uploadURL, fields, filePath)
+}
+
+// UploadFileWithContext uploads a file to S3 using the provided credentials
+func (s *AttachmentsService) UploadFileWithContext(ctx context.Context, uploadURL string, fields map[string]string, filePath string) error {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
+	return s.upload(ctx, uploadURL, fields, filepath.Base(filePath), file)
+}
+
+// upload posts the content of r to the storage URL returned with the upload credentials
+func (s *AttachmentsService) upload(ctx context.Context, uploadURL string, fields map[string]string, filename string, r io.Reader) error {
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
 
@@ -101,12 +147,12 @@ func (s *AttachmentsService) UploadFile(uploadURL string, fields map[string]stri
 	}
 
 	// Add file
-	fileWriter, err := writer.CreateFormFile("file", filepath.Base(filePath))
+	fileWriter, err := writer.CreateFormFile("file", filename)
 	if err != nil {
 		return err
 	}
 
-	if _, err = io.Copy(fileWriter, file); err != nil {
+	if _, err = io.Copy(fileWriter, r); err != nil {
 		return err
 	}
 
@@ -114,15 +160,15 @@ func (s *AttachmentsService) UploadFile(uploadURL string, fields map[string]stri
 		return err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, uploadURL, body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, body)
 	if err != nil {
 		return err
 	}
 
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	// 使用客户端配置的 HTTP 客户端，以便代理、mTLS 和超时设置同样生效
+	resp, err := s.client.HTTPClient().Do(req)
 	if err != nil {
 		return err
 	}
@@ -140,6 +186,11 @@ func (s *AttachmentsService) UploadFile(uploadURL string, fields map[string]stri
 // UploadFileToIssue uploads a file to an issue in a single step
 // 一步完成文件上传到问题，包括获取上传凭证、上传文件和完成上传过程
 func (s *AttachmentsService) UploadFileToIssue(workspaceSlug string, projectID string, issueID string, filePath string) (*models.Attachment, error) {
+	return s.UploadFileToIssueWithContext(context.Background(), workspaceSlug, projectID, issueID, filePath)
+}
+
+// UploadFileToIssueWithContext uploads a file to an issue in a single step
+func (s *AttachmentsService) UploadFileToIssueWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, filePath string) (*models.Attachment, error) {
 	// 获取文件信息
 	file, err := os.Open(filePath)
 	if err != nil {
@@ -170,20 +221,30 @@ func (s *AttachmentsService) UploadFileToIssue(workspaceSlug string, projectID s
 
 	contentType := http.DetectContentType(buffer)
 
+	return s.UploadToIssueWithContext(ctx, workspaceSlug, projectID, issueID, filename, contentType, fileSize, file)
+}
+
+// UploadToIssue uploads size bytes read from r to an issue as a file named filename
+func (s *AttachmentsService) UploadToIssue(workspaceSlug string, projectID string, issueID string, filename string, contentType string, size int64, r io.Reader) (*models.Attachment, error) {
+	return s.UploadToIssueWithContext(context.Background(), workspaceSlug, projectID, issueID, filename, contentType, size, r)
+}
+
+// UploadToIssueWithContext uploads size bytes read from r to an issue as a file named filename
+func (s *AttachmentsService) UploadToIssueWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, filename string, contentType string, size int64, r io.Reader) (*models.Attachment, error) {
 	// 获取上传凭证
-	credentials, err := s.GetUploadCredentials(workspaceSlug, projectID, issueID, filename, contentType, fileSize)
+	credentials, err := s.GetUploadCredentialsWithContext(ctx, workspaceSlug, projectID, issueID, filename, contentType, size)
 	if err != nil {
 		return nil, fmt.Errorf("获取上传凭证失败: %w", err)
 	}
 
 	// 上传文件
-	err = s.UploadFile(credentials.UploadData.URL, credentials.UploadData.Fields, filePath)
+	err = s.upload(ctx, credentials.UploadData.URL, credentials.UploadData.Fields, filename, r)
 	if err != nil {
 		return nil, fmt.Errorf("上传文件失败: %w", err)
 	}
 
 	// 完成上传过程
-	attachment, err := s.CompleteUpload(workspaceSlug, projectID, issueID, credentials.AssetID)
+	attachment, err := s.CompleteUploadWithContext(ctx, workspaceSlug, projectID, issueID, credentials.AssetID)
 	if err != nil {
 		return nil, fmt.Errorf("完成上传过程失败: %w", err)
 	}
@@ -1,10 +1,11 @@
 package api
 
 import (
+	"bytes"
 	"os"
+	"strings"
 	"testing"
 
-	"github.com/GeekWorkCode/plane-api-go/client"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -13,18 +14,15 @@ import (
 func TestAttachmentsService(t *testing.T) {
 	// Initialize test client
 	// 初始化测试客户端
-	apiKey := os.Getenv("PLANE_API_KEY")
-	if apiKey == "" {
-		t.Skip("PLANE_API_KEY not set")
-	}
-	c := client.NewClient(apiKey)
+	env := newTestEnv(t)
+	c := env.client
 	s := NewAttachmentsService(c)
 
 	// Test data
 	// 测试数据
-	workspaceSlug := os.Getenv("PLANE_WORKSPACE_SLUG")
-	projectID := os.Getenv("PLANE_PROJECT_ID")
-	issueID := os.Getenv("PLANE_ISSUE_ID")
+	workspaceSlug := env.workspaceSlug
+	projectID := env.projectID
+	issueID := env.issueID
 
 	if workspaceSlug == "" || projectID == "" || issueID == "" {
 		t.Skip("Required environment variables not set")
@@ -117,6 +115,22 @@ func TestAttachmentsService(t *testing.T) {
 		attachmentID = attachment.ID
 	})
 
+	// Test UploadToIssue and Download methods
+	// 测试 UploadToIssue 和 Download 方法
+	t.Run("UploadToIssueAndDownload", func(t *testing.T) {
+		content := "Uploaded from a reader"
+		attachment, err := s.UploadToIssue(workspaceSlug, projectID, issueID, "reader.txt", "text/plain", int64(len(content)), strings.NewReader(content))
+		if !assert.NoError(t, err) {
+			return
+		}
+		attachmentID = attachment.ID
+
+		var downloaded bytes.Buffer
+		err = s.Download(workspaceSlug, projectID, issueID, attachment.ID, &downloaded)
+		assert.NoError(t, err)
+		assert.Equal(t, content, downloaded.String())
+	})
+
 	// Clean up
 	// 清理
 	if attachmentID != "" {
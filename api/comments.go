@@ -1,54 +1,89 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
 	"github.com/GeekWorkCode/plane-api-go/client"
+	"github.com/GeekWorkCode/plane-api-go/markdown"
 	"github.com/GeekWorkCode/plane-api-go/models"
 )
 
 // CommentsService handles communication with the issue comments related endpoints
 type CommentsService struct {
-	client *client.Client
+	client   *client.Client
+	resolver *Resolver
 }
 
 // NewCommentsService creates a new comments service
 func NewCommentsService(client *client.Client) *CommentsService {
 	return &CommentsService{
-		client: client,
+		client:   client,
+		resolver: NewResolver(client, DefaultResolverTTL),
 	}
 }
 
+// SetResolver makes the service share a name resolver with other services
+func (s *CommentsService) SetResolver(resolver *Resolver) {
+	s.resolver = resolver
+}
+
 // CommentRequest represents the request body for creating or updating a comment
 type CommentRequest struct {
-	CommentHTML string `json:"comment_html"`
-	CreatedBy   string `json:"created_by,omitempty"` // ID of the member who created the comment
-	Actor       string `json:"actor,omitempty"`      // ID of the member who updated the comment (used for update only)
-	DisplayName string `json:"-"`                    // 成员显示名称，不会直接发送到API
+	CommentHTML    string `json:"comment_html"`
+	Markdown       string `json:"-"`                    // Markdown 评论内容, 转换为 CommentHTML (不发送到API)
+	ExpandMentions bool   `json:"-"`                    // 将评论中的 @显示名称 和 @邮箱 替换为成员提及 (不发送到API)
+	CreatedBy      string `json:"created_by,omitempty"` // ID of the member who created the comment
+	Actor          string `json:"actor,omitempty"`      // ID of the member who updated the comment (used for update only)
+	DisplayName    string `json:"-"`                    // 成员显示名称，不会直接发送到API
 }
 
 // List returns all comments for an issue
 func (s *CommentsService) List(workspaceSlug string, projectID string, issueID string) ([]models.Comment, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/issues/%s/comments/", workspaceSlug, projectID, issueID)
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	return s.ListWithContext(context.Background(), workspaceSlug, projectID, issueID)
+}
+
+// ListWithContext returns all comments for an issue, following pagination until every page is fetched
+func (s *CommentsService) ListWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string) ([]models.Comment, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/comments/", workspaceSlug, projectID, issueID)
+	results, err := listAll[models.Comment](ctx, s.client, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("创建请求失败: %w", err)
+		return nil, fmt.Errorf("获取评论列表失败: %w", err)
 	}
+	return results, nil
+}
 
-	response := new(models.CommentsResponse)
-	_, err = s.client.Do(req, response)
+// ListPage returns a single page of comments for an issue
+func (s *CommentsService) ListPage(workspaceSlug string, projectID string, issueID string, opts *ListOptions) (*models.PaginatedResponse[models.Comment], error) {
+	return s.ListPageWithContext(context.Background(), workspaceSlug, projectID, issueID, opts)
+}
+
+// ListPageWithContext returns a single page of comments for an issue
+func (s *CommentsService) ListPageWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, opts *ListOptions) (*models.PaginatedResponse[models.Comment], error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/comments/", workspaceSlug, projectID, issueID)
+	page, err := listPage[models.Comment](ctx, s.client, path, opts.values())
 	if err != nil {
 		return nil, fmt.Errorf("获取评论列表失败: %w", err)
 	}
+	return page, nil
+}
 
-	return response.Results, nil
+// Iterate returns an iterator over all comments for an issue, starting at opts.Cursor
+func (s *CommentsService) Iterate(ctx context.Context, workspaceSlug string, projectID string, issueID string, opts *ListOptions) *Iterator[models.Comment] {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/comments/", workspaceSlug, projectID, issueID)
+	return newListIterator[models.Comment](ctx, s.client, path, opts.values())
 }
 
 // Get returns a comment by its ID
 func (s *CommentsService) Get(workspaceSlug string, projectID string, issueID string, commentID string) (*models.Comment, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/issues/%s/comments/%s/", workspaceSlug, projectID, issueID, commentID)
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	return s.GetWithContext(context.Background(), workspaceSlug, projectID, issueID, commentID)
+}
+
+// GetWithContext returns a comment by its ID
+func (s *CommentsService) GetWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, commentID string) (*models.Comment, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/comments/%s/", workspaceSlug, projectID, issueID, commentID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, fmt.Errorf("创建请求失败: %w", err)
 	}
@@ -62,30 +97,30 @@ func (s *CommentsService) Get(workspaceSlug string, projectID string, issueID st
 }
 
 // 根据显示名称查找成员ID
-func (s *CommentsService) findMemberIDByDisplayName(workspaceSlug string, projectID string, displayName string) (string, error) {
-	// 使用Members服务获取所有成员
-	membersService := NewMembersService(s.client)
-	members, err := membersService.List(workspaceSlug, projectID)
-	if err != nil {
-		return "", fmt.Errorf("获取成员列表失败: %w", err)
-	}
+func (s *CommentsService) findMemberIDByDisplayName(ctx context.Context, workspaceSlug string, projectID string, displayName string) (string, error) {
+	return s.resolver.MemberID(ctx, workspaceSlug, projectID, displayName)
+}
 
-	// 查找匹配DisplayName的成员
-	for _, member := range members {
-		if member.Member.DisplayName == displayName {
-			return member.Member.ID, nil
+// prepareCommentRequest 处理评论请求，如果提供了DisplayName则转换为MemberID
+func (s *CommentsService) prepareCommentRequest(ctx context.Context, workspaceSlug string, projectID string, request *CommentRequest) error {
+	// 转换后清除 Markdown 和 ExpandMentions, 再次发送同一请求时不会重复转换
+	if request.Markdown != "" {
+		request.CommentHTML = markdown.ToHTML(request.Markdown)
+		request.Markdown = ""
+	}
+	if request.ExpandMentions {
+		html, err := expandMentions(ctx, s.resolver, workspaceSlug, projectID, request.CommentHTML)
+		if err != nil {
+			return err
 		}
+		request.CommentHTML = html
+		request.ExpandMentions = false
 	}
 
-	return "", fmt.Errorf("找不到显示名称为 '%s' 的成员", displayName)
-}
-
-// prepareCommentRequest 处理评论请求，如果提供了DisplayName则转换为MemberID
-func (s *CommentsService) prepareCommentRequest(workspaceSlug string, projectID string, request *CommentRequest) error {
 	// 如果提供了DisplayName但没有CreatedBy/Actor，尝试查找对应的MemberID
 	if request.DisplayName != "" {
 		if request.CreatedBy == "" && request.Actor == "" {
-			memberID, err := s.findMemberIDByDisplayName(workspaceSlug, projectID, request.DisplayName)
+			memberID, err := s.findMemberIDByDisplayName(ctx, workspaceSlug, projectID, request.DisplayName)
 			if err != nil {
 				return err
 			}
@@ -103,14 +138,19 @@ func (s *CommentsService) prepareCommentRequest(workspaceSlug string, projectID
 // Create creates a new comment
 // 支持通过DisplayName或CreatedBy(MemberID)创建评论
 func (s *CommentsService) Create(workspaceSlug string, projectID string, issueID string, request *CommentRequest) (*models.Comment, error) {
+	return s.CreateWithContext(context.Background(), workspaceSlug, projectID, issueID, request)
+}
+
+// CreateWithContext creates a new comment
+func (s *CommentsService) CreateWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, request *CommentRequest) (*models.Comment, error) {
 	// 处理DisplayName，如果有的话
-	err := s.prepareCommentRequest(workspaceSlug, projectID, request)
+	err := s.prepareCommentRequest(ctx, workspaceSlug, projectID, request)
 	if err != nil {
 		return nil, err
 	}
 
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/issues/%s/comments/", workspaceSlug, projectID, issueID)
-	req, err := s.client.NewRequest(http.MethodPost, path, request)
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/comments/", workspaceSlug, projectID, issueID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, path, request)
 	if err != nil {
 		return nil, fmt.Errorf("创建请求失败: %w", err)
 	}
@@ -134,23 +174,29 @@ func (s *CommentsService) Create(workspaceSlug string, projectID string, issueID
 		}
 
 		// 执行更新操作
-		updatedComment, updateErr := s.Update(workspaceSlug, projectID, issueID, comment.ID, updateReq)
+		updatedComment, updateErr := s.UpdateWithContext(ctx, workspaceSlug, projectID, issueID, comment.ID, updateReq)
 		if updateErr == nil {
 			// 如果更新成功，返回更新后的评论
 			return updatedComment, nil
 		}
 		// 如果更新失败，记录日志但继续返回原始创建的评论
-		fmt.Printf("警告: 尝试修正评论作者显示失败: %v\n", updateErr)
+		s.client.Logger().Warn("尝试修正评论作者显示失败",
+			"comment_id", comment.ID,
+			"error", updateErr)
 	}
 
 	// If a memberID was provided and the comment was created successfully,
 	// but the API didn't populate the Member field, we'll set it manually
 	if request.CreatedBy != "" && comment.Member == nil {
 		// Fetch member details if needed
-		membersService := NewMembersService(s.client)
-		member, err := membersService.Get(workspaceSlug, projectID, request.CreatedBy)
-		if err == nil && member != nil {
-			comment.Member = &member.Member
+		members, err := s.resolver.Members(ctx, workspaceSlug, projectID)
+		if err == nil {
+			for i := range members {
+				if members[i].Member.ID == request.CreatedBy {
+					comment.Member = &members[i].Member
+					break
+				}
+			}
 		}
 	}
 
@@ -160,14 +206,19 @@ func (s *CommentsService) Create(workspaceSlug string, projectID string, issueID
 // Update updates a comment
 // 支持通过DisplayName或Actor(MemberID)更新评论
 func (s *CommentsService) Update(workspaceSlug string, projectID string, issueID string, commentID string, request *CommentRequest) (*models.Comment, error) {
+	return s.UpdateWithContext(context.Background(), workspaceSlug, projectID, issueID, commentID, request)
+}
+
+// UpdateWithContext updates a comment
+func (s *CommentsService) UpdateWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, commentID string, request *CommentRequest) (*models.Comment, error) {
 	// 处理DisplayName，如果有的话
-	err := s.prepareCommentRequest(workspaceSlug, projectID, request)
+	err := s.prepareCommentRequest(ctx, workspaceSlug, projectID, request)
 	if err != nil {
 		return nil, err
 	}
 
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/issues/%s/comments/%s/", workspaceSlug, projectID, issueID, commentID)
-	req, err := s.client.NewRequest(http.MethodPatch, path, request)
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/comments/%s/", workspaceSlug, projectID, issueID, commentID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPatch, path, request)
 	if err != nil {
 		return nil, fmt.Errorf("创建请求失败: %w", err)
 	}
@@ -183,8 +234,13 @@ func (s *CommentsService) Update(workspaceSlug string, projectID string, issueID
 
 // Delete deletes a comment
 func (s *CommentsService) Delete(workspaceSlug string, projectID string, issueID string, commentID string) error {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/issues/%s/comments/%s/", workspaceSlug, projectID, issueID, commentID)
-	req, err := s.client.NewRequest(http.MethodDelete, path, nil)
+	return s.DeleteWithContext(context.Background(), workspaceSlug, projectID, issueID, commentID)
+}
+
+// DeleteWithContext deletes a comment
+func (s *CommentsService) DeleteWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, commentID string) error {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/comments/%s/", workspaceSlug, projectID, issueID, commentID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodDelete, path, nil)
 	if err != nil {
 		return fmt.Errorf("创建请求失败: %w", err)
 	}
@@ -4,30 +4,26 @@ import (
 	"os"
 	"testing"
 
-	"github.com/GeekWorkCode/plane-api-go/client"
 	"github.com/stretchr/testify/assert"
 )
 
 // TestCommentsService tests all methods of the CommentsService
 // 测试 CommentsService 的所有方法
 func TestCommentsService(t *testing.T) {
-	// Get API key from environment
-	apiKey := os.Getenv("PLANE_API_KEY")
-	if apiKey == "" {
-		t.Skip("PLANE_API_KEY not set")
-	}
+	// Use the live API if configured, the fake server otherwise
+	env := newTestEnv(t)
 
-	// Get required IDs from environment
-	workspaceSlug := os.Getenv("PLANE_WORKSPACE_SLUG")
-	projectID := os.Getenv("PLANE_PROJECT_ID")
-	issueID := os.Getenv("PLANE_ISSUE_ID")
+	// Get required IDs from the test environment
+	workspaceSlug := env.workspaceSlug
+	projectID := env.projectID
+	issueID := env.issueID
 
 	if workspaceSlug == "" || projectID == "" || issueID == "" {
 		t.Skip("Required environment variables not set")
 	}
 
 	// Create a new client
-	client := client.NewClient(apiKey)
+	client := env.client
 	s := NewCommentsService(client)
 
 	var commentID string
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -10,16 +11,23 @@ import (
 
 // CyclesService handles communication with the cycle related endpoints
 type CyclesService struct {
-	client *client.Client
+	client   *client.Client
+	resolver *Resolver
 }
 
 // NewCyclesService creates a new cycles service
 func NewCyclesService(client *client.Client) *CyclesService {
 	return &CyclesService{
-		client: client,
+		client:   client,
+		resolver: NewResolver(client, DefaultResolverTTL),
 	}
 }
 
+// SetResolver makes the service share a name resolver with other services
+func (s *CyclesService) SetResolver(resolver *Resolver) {
+	s.resolver = resolver
+}
+
 // CycleCreateRequest represents the request body for creating a cycle
 type CycleCreateRequest struct {
 	Name        string `json:"name"`
@@ -38,25 +46,43 @@ type CycleUpdateRequest struct {
 
 // List returns all cycles in a project
 func (s *CyclesService) List(workspaceSlug string, projectID string) ([]models.Cycle, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/cycles/", workspaceSlug, projectID)
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
-	if err != nil {
-		return nil, err
-	}
+	return s.ListWithContext(context.Background(), workspaceSlug, projectID)
+}
 
-	response := new(models.CyclesResponse)
-	_, err = s.client.Do(req, response)
-	if err != nil {
-		return nil, err
-	}
+// ListWithContext returns all cycles in a project, following pagination until every page is fetched
+func (s *CyclesService) ListWithContext(ctx context.Context, workspaceSlug string, projectID string) ([]models.Cycle, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/cycles/", workspaceSlug, projectID)
+	results, err := listAll[models.Cycle](ctx, s.client, path, nil)
+	return results, err
+}
+
+// ListPage returns a single page of cycles in a project
+func (s *CyclesService) ListPage(workspaceSlug string, projectID string, opts *ListOptions) (*models.PaginatedResponse[models.Cycle], error) {
+	return s.ListPageWithContext(context.Background(), workspaceSlug, projectID, opts)
+}
+
+// ListPageWithContext returns a single page of cycles in a project
+func (s *CyclesService) ListPageWithContext(ctx context.Context, workspaceSlug string, projectID string, opts *ListOptions) (*models.PaginatedResponse[models.Cycle], error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/cycles/", workspaceSlug, projectID)
+	page, err := listPage[models.Cycle](ctx, s.client, path, opts.values())
+	return page, err
+}
 
-	return response.Results, nil
+// Iterate returns an iterator over all cycles in a project, starting at opts.Cursor
+func (s *CyclesService) Iterate(ctx context.Context, workspaceSlug string, projectID string, opts *ListOptions) *Iterator[models.Cycle] {
+	path := client.Pathf("/workspaces/%s/projects/%s/cycles/", workspaceSlug, projectID)
+	return newListIterator[models.Cycle](ctx, s.client, path, opts.values())
 }
 
 // Get returns a cycle by its ID
 func (s *CyclesService) Get(workspaceSlug string, projectID string, cycleID string) (*models.Cycle, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/cycles/%s/", workspaceSlug, projectID, cycleID)
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	return s.GetWithContext(context.Background(), workspaceSlug, projectID, cycleID)
+}
+
+// GetWithContext returns a cycle by its ID
+func (s *CyclesService) GetWithContext(ctx context.Context, workspaceSlug string, projectID string, cycleID string) (*models.Cycle, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/cycles/%s/", workspaceSlug, projectID, cycleID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -68,8 +94,13 @@ func (s *CyclesService) Get(workspaceSlug string, projectID string, cycleID stri
 
 // Create creates a new cycle
 func (s *CyclesService) Create(workspaceSlug string, projectID string, createRequest *CycleCreateRequest) (*models.Cycle, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/cycles/", workspaceSlug, projectID)
-	req, err := s.client.NewRequest(http.MethodPost, path, createRequest)
+	return s.CreateWithContext(context.Background(), workspaceSlug, projectID, createRequest)
+}
+
+// CreateWithContext creates a new cycle
+func (s *CyclesService) CreateWithContext(ctx context.Context, workspaceSlug string, projectID string, createRequest *CycleCreateRequest) (*models.Cycle, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/cycles/", workspaceSlug, projectID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, path, createRequest)
 	if err != nil {
 		return nil, fmt.Errorf("创建请求失败: %w", err)
 	}
@@ -83,31 +114,44 @@ func (s *CyclesService) Create(workspaceSlug string, projectID string, createReq
 		}
 		return nil, fmt.Errorf("创建周期失败: %w", err)
 	}
+	s.resolver.invalidate(kindCycles, workspaceSlug, projectID)
 	return cycle, nil
 }
 
 // Update updates a cycle
 func (s *CyclesService) Update(workspaceSlug string, projectID string, cycleID string, updateRequest *CycleUpdateRequest) (*models.Cycle, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/cycles/%s/", workspaceSlug, projectID, cycleID)
-	req, err := s.client.NewRequest(http.MethodPatch, path, updateRequest)
+	return s.UpdateWithContext(context.Background(), workspaceSlug, projectID, cycleID, updateRequest)
+}
+
+// UpdateWithContext updates a cycle
+func (s *CyclesService) UpdateWithContext(ctx context.Context, workspaceSlug string, projectID string, cycleID string, updateRequest *CycleUpdateRequest) (*models.Cycle, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/cycles/%s/", workspaceSlug, projectID, cycleID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPatch, path, updateRequest)
 	if err != nil {
 		return nil, err
 	}
 
 	cycle := new(models.Cycle)
 	_, err = s.client.Do(req, cycle)
+	s.resolver.invalidate(kindCycles, workspaceSlug, projectID)
 	return cycle, err
 }
 
 // Delete deletes a cycle
 func (s *CyclesService) Delete(workspaceSlug string, projectID string, cycleID string) error {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/cycles/%s/", workspaceSlug, projectID, cycleID)
-	req, err := s.client.NewRequest(http.MethodDelete, path, nil)
+	return s.DeleteWithContext(context.Background(), workspaceSlug, projectID, cycleID)
+}
+
+// DeleteWithContext deletes a cycle
+func (s *CyclesService) DeleteWithContext(ctx context.Context, workspaceSlug string, projectID string, cycleID string) error {
+	path := client.Pathf("/workspaces/%s/projects/%s/cycles/%s/", workspaceSlug, projectID, cycleID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodDelete, path, nil)
 	if err != nil {
 		return err
 	}
 
 	_, err = s.client.Do(req, nil)
+	s.resolver.invalidate(kindCycles, workspaceSlug, projectID)
 	return err
 }
 
@@ -118,22 +162,44 @@ type CycleIssueAddRequest struct {
 
 // ListIssues returns all issues in a cycle
 func (s *CyclesService) ListIssues(workspaceSlug string, projectID string, cycleID string) ([]models.Issue, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/cycles/%s/cycle-issues/", workspaceSlug, projectID, cycleID)
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
-	if err != nil {
-		return nil, err
-	}
+	return s.ListIssuesWithContext(context.Background(), workspaceSlug, projectID, cycleID)
+}
+
+// ListIssuesWithContext returns all issues in a cycle, following pagination until every page is fetched
+func (s *CyclesService) ListIssuesWithContext(ctx context.Context, workspaceSlug string, projectID string, cycleID string) ([]models.Issue, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/cycles/%s/cycle-issues/", workspaceSlug, projectID, cycleID)
+	results, err := listAll[models.Issue](ctx, s.client, path, nil)
+	return results, err
+}
+
+// ListIssuesPage returns a single page of issues in a cycle
+func (s *CyclesService) ListIssuesPage(workspaceSlug string, projectID string, cycleID string, opts *ListOptions) (*models.PaginatedResponse[models.Issue], error) {
+	return s.ListIssuesPageWithContext(context.Background(), workspaceSlug, projectID, cycleID, opts)
+}
+
+// ListIssuesPageWithContext returns a single page of issues in a cycle
+func (s *CyclesService) ListIssuesPageWithContext(ctx context.Context, workspaceSlug string, projectID string, cycleID string, opts *ListOptions) (*models.PaginatedResponse[models.Issue], error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/cycles/%s/cycle-issues/", workspaceSlug, projectID, cycleID)
+	page, err := listPage[models.Issue](ctx, s.client, path, opts.values())
+	return page, err
+}
 
-	var issues []models.Issue
-	_, err = s.client.Do(req, &issues)
-	return issues, err
+// IterateIssues returns an iterator over all issues in a cycle, starting at opts.Cursor
+func (s *CyclesService) IterateIssues(ctx context.Context, workspaceSlug string, projectID string, cycleID string, opts *ListOptions) *Iterator[models.Issue] {
+	path := client.Pathf("/workspaces/%s/projects/%s/cycles/%s/cycle-issues/", workspaceSlug, projectID, cycleID)
+	return newListIterator[models.Issue](ctx, s.client, path, opts.values())
 }
 
 // AddIssues adds issues to a cycle
 func (s *CyclesService) AddIssues(workspaceSlug string, projectID string, cycleID string, issueIDs []string) error {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/cycles/%s/cycle-issues/", workspaceSlug, projectID, cycleID)
+	return s.AddIssuesWithContext(context.Background(), workspaceSlug, projectID, cycleID, issueIDs)
+}
+
+// AddIssuesWithContext adds issues to a cycle
+func (s *CyclesService) AddIssuesWithContext(ctx context.Context, workspaceSlug string, projectID string, cycleID string, issueIDs []string) error {
+	path := client.Pathf("/workspaces/%s/projects/%s/cycles/%s/cycle-issues/", workspaceSlug, projectID, cycleID)
 
-	req, err := s.client.NewRequest(http.MethodPost, path, &CycleIssueAddRequest{
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, path, &CycleIssueAddRequest{
 		Issues: issueIDs,
 	})
 	if err != nil {
@@ -146,8 +212,13 @@ func (s *CyclesService) AddIssues(workspaceSlug string, projectID string, cycleI
 
 // RemoveIssue removes an issue from a cycle
 func (s *CyclesService) RemoveIssue(workspaceSlug string, projectID string, cycleID string, issueID string) error {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/cycles/%s/cycle-issues/%s/", workspaceSlug, projectID, cycleID, issueID)
-	req, err := s.client.NewRequest(http.MethodDelete, path, nil)
+	return s.RemoveIssueWithContext(context.Background(), workspaceSlug, projectID, cycleID, issueID)
+}
+
+// RemoveIssueWithContext removes an issue from a cycle
+func (s *CyclesService) RemoveIssueWithContext(ctx context.Context, workspaceSlug string, projectID string, cycleID string, issueID string) error {
+	path := client.Pathf("/workspaces/%s/projects/%s/cycles/%s/cycle-issues/%s/", workspaceSlug, projectID, cycleID, issueID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodDelete, path, nil)
 	if err != nil {
 		return err
 	}
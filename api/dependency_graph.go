@@ -0,0 +1,277 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GeekWorkCode/plane-api-go/models"
+)
+
+// DependencyGraph holds the blocking relations between the issues of a project.
+// Issues in a completed or cancelled state are resolved and no longer block others.
+// Blockers from other projects are included and treated as unresolved.
+// 项目中问题之间的阻塞关系图
+type DependencyGraph struct {
+	issues   map[string]*models.Issue
+	order    map[string]int      // issue ID -> position, for a stable output order
+	resolved map[string]bool     // issue ID -> in a completed or cancelled state
+	blockers map[string][]string // issue ID -> issues blocking it
+	blocks   map[string][]string // issue ID -> issues it blocks
+}
+
+// newDependencyGraph creates a graph of the issues without relations
+func newDependencyGraph(issues []models.Issue, states []models.State) *DependencyGraph {
+	g := &DependencyGraph{
+		issues:   map[string]*models.Issue{},
+		order:    map[string]int{},
+		resolved: map[string]bool{},
+		blockers: map[string][]string{},
+		blocks:   map[string][]string{},
+	}
+	groups := map[string]string{}
+	for _, state := range states {
+		groups[state.ID] = state.Group
+	}
+	for i := range issues {
+		issue := &issues[i]
+		g.issues[issue.ID] = issue
+		g.node(issue.ID)
+		switch groups[issue.State] {
+		case models.StateGroupCompleted, models.StateGroupCancelled:
+			g.resolved[issue.ID] = true
+		}
+	}
+	return g
+}
+
+// node registers an issue ID, keeping the order in which IDs were first seen
+func (g *DependencyGraph) node(id string) {
+	if _, ok := g.order[id]; !ok {
+		g.order[id] = len(g.order)
+	}
+}
+
+// addBlock records that blocker blocks blocked, ignoring duplicates
+func (g *DependencyGraph) addBlock(blocker string, blocked string) {
+	g.node(blocker)
+	g.node(blocked)
+	for _, id := range g.blocks[blocker] {
+		if id == blocked {
+			return
+		}
+	}
+	g.blocks[blocker] = append(g.blocks[blocker], blocked)
+	g.blockers[blocked] = append(g.blockers[blocked], blocker)
+}
+
+// sorted returns the IDs of the graph in a stable order
+func (g *DependencyGraph) sorted() []string {
+	ids := make([]string, len(g.order))
+	for id, i := range g.order {
+		ids[i] = id
+	}
+	return ids
+}
+
+// Issue returns an issue of the project, or nil for IDs outside the project
+func (g *DependencyGraph) Issue(issueID string) *models.Issue {
+	return g.issues[issueID]
+}
+
+// Blockers returns the IDs of the issues directly blocking an issue
+func (g *DependencyGraph) Blockers(issueID string) []string {
+	return g.blockers[issueID]
+}
+
+// OpenBlockers returns the IDs of the unresolved issues directly blocking an issue
+func (g *DependencyGraph) OpenBlockers(issueID string) []string {
+	var open []string
+	for _, id := range g.blockers[issueID] {
+		if !g.resolved[id] {
+			open = append(open, id)
+		}
+	}
+	return open
+}
+
+// IsBlocked reports whether an issue is blocked by an unresolved issue
+func (g *DependencyGraph) IsBlocked(issueID string) bool {
+	return len(g.OpenBlockers(issueID)) > 0
+}
+
+// Cycles returns the groups of issues that block each other, directly or
+// through other issues. Such issues can never be unblocked.
+func (g *DependencyGraph) Cycles() [][]string {
+	// Tarjan 强连通分量算法
+	index := map[string]int{}
+	low := map[string]int{}
+	onStack := map[string]bool{}
+	var stack []string
+	var cycles [][]string
+
+	var visit func(id string)
+	visit = func(id string) {
+		index[id], low[id] = len(index), len(index)
+		stack = append(stack, id)
+		onStack[id] = true
+		for _, next := range g.blocks[id] {
+			if _, seen := index[next]; !seen {
+				visit(next)
+				if low[next] < low[id] {
+					low[id] = low[next]
+				}
+			} else if onStack[next] && index[next] < low[id] {
+				low[id] = index[next]
+			}
+		}
+		if low[id] != index[id] {
+			return
+		}
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == id {
+				break
+			}
+		}
+		if len(component) > 1 || g.blocksItself(id) {
+			cycles = append(cycles, g.inOrder(component))
+		}
+	}
+	for _, id := range g.sorted() {
+		if _, seen := index[id]; !seen {
+			visit(id)
+		}
+	}
+	return cycles
+}
+
+func (g *DependencyGraph) blocksItself(id string) bool {
+	for _, next := range g.blocks[id] {
+		if next == id {
+			return true
+		}
+	}
+	return false
+}
+
+// inOrder sorts IDs by their position in the graph
+func (g *DependencyGraph) inOrder(ids []string) []string {
+	sorted := make([]string, 0, len(ids))
+	for _, id := range g.sorted() {
+		for _, other := range ids {
+			if id == other {
+				sorted = append(sorted, id)
+			}
+		}
+	}
+	return sorted
+}
+
+// BlockedChains returns chains of unresolved issues blocking each other. For
+// every unresolved issue at the end of a chain, which is blocked but blocks no
+// unresolved issue, it returns the longest chain leading to it, starting with an
+// issue that is not blocked itself. Chains are in the order of their last issue,
+// and each has at least two issues; issues in cycles are left out, see Cycles.
+func (g *DependencyGraph) BlockedChains() [][]string {
+	excluded := map[string]bool{}
+	for _, cycle := range g.Cycles() {
+		for _, id := range cycle {
+			excluded[id] = true
+		}
+	}
+	open := func(id string) bool { return !g.resolved[id] && !excluded[id] }
+
+	// 去掉循环后未解决的问题构成有向无环图, 按拓扑顺序计算以每个问题结尾的最长链
+	ids := g.sorted()
+	indegree := map[string]int{}
+	for _, id := range ids {
+		if !open(id) {
+			continue
+		}
+		for _, next := range g.blocks[id] {
+			if open(next) {
+				indegree[next]++
+			}
+		}
+	}
+	var queue []string
+	for _, id := range ids {
+		if open(id) && indegree[id] == 0 {
+			queue = append(queue, id)
+		}
+	}
+	length := map[string]int{}      // issue ID -> number of issues in the longest chain ending with it
+	previous := map[string]string{} // issue ID -> the issue before it in that chain
+	blocksOpen := map[string]bool{}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if length[id] == 0 {
+			length[id] = 1
+		}
+		for _, next := range g.blocks[id] {
+			if !open(next) {
+				continue
+			}
+			blocksOpen[id] = true
+			// 长度相同时选择排在前面的问题, 使结果稳定
+			if length[id]+1 > length[next] || (length[id]+1 == length[next] && g.order[id] < g.order[previous[next]]) {
+				length[next] = length[id] + 1
+				previous[next] = id
+			}
+			if indegree[next]--; indegree[next] == 0 {
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	var chains [][]string
+	for _, id := range ids {
+		if !open(id) || blocksOpen[id] || length[id] < 2 {
+			continue
+		}
+		chain := make([]string, length[id])
+		for i, cur := len(chain)-1, id; i >= 0; i, cur = i-1, previous[cur] {
+			chain[i] = cur
+		}
+		chains = append(chains, chain)
+	}
+	return chains
+}
+
+// DependencyGraph builds the dependency graph of the issues in a project
+// from their blocking relations. It requests the relations of every issue.
+func (s *IssueRelationsService) DependencyGraph(workspaceSlug string, projectID string) (*DependencyGraph, error) {
+	return s.DependencyGraphWithContext(context.Background(), workspaceSlug, projectID)
+}
+
+// DependencyGraphWithContext builds the dependency graph of the issues in a project
+func (s *IssueRelationsService) DependencyGraphWithContext(ctx context.Context, workspaceSlug string, projectID string) (*DependencyGraph, error) {
+	issues, err := NewIssuesService(s.client).ListWithContext(ctx, workspaceSlug, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("获取问题列表失败: %w", err)
+	}
+	states, err := NewStatesService(s.client).ListWithContext(ctx, workspaceSlug, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("获取状态列表失败: %w", err)
+	}
+
+	g := newDependencyGraph(issues, states)
+	for _, issue := range issues {
+		relations, err := s.ListWithContext(ctx, workspaceSlug, projectID, issue.ID)
+		if err != nil {
+			return nil, fmt.Errorf("获取问题 %s 的关系失败: %w", issue.ID, err)
+		}
+		for _, blocker := range relations.BlockedBy {
+			g.addBlock(blocker.ID, issue.ID)
+		}
+		for _, blocked := range relations.Blocking {
+			g.addBlock(issue.ID, blocked.ID)
+		}
+	}
+	return g, nil
+}
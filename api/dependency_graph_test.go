@@ -0,0 +1,109 @@
+package api
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/GeekWorkCode/plane-api-go/client"
+	"github.com/GeekWorkCode/plane-api-go/models"
+	"github.com/GeekWorkCode/plane-api-go/planetest"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDependencyGraph tests blocked chains and cycles of a dependency graph
+// 测试依赖图中的阻塞链和循环
+func TestDependencyGraph(t *testing.T) {
+	states := []models.State{
+		{ID: "todo", Group: models.StateGroupUnstarted},
+		{ID: "done", Group: models.StateGroupCompleted},
+	}
+	var issues []models.Issue
+	for _, id := range []string{"a", "b", "c", "d", "e", "x", "y", "z"} {
+		issues = append(issues, models.Issue{ID: id, State: "todo"})
+	}
+	issues[3].State = "done" // d
+
+	g := newDependencyGraph(issues, states)
+	g.addBlock("a", "b")
+	g.addBlock("b", "c")
+	g.addBlock("a", "e")
+	g.addBlock("d", "e") // d 已完成, 不再阻塞 e
+	g.addBlock("x", "y")
+	g.addBlock("y", "z")
+	g.addBlock("z", "x")
+	g.addBlock("z", "c")
+	g.addBlock("a", "b") // 重复的关系被忽略
+
+	assert.Equal(t, []string{"a"}, g.Blockers("b"))
+	assert.Equal(t, []string{"a", "d"}, g.Blockers("e"))
+	assert.Equal(t, []string{"a"}, g.OpenBlockers("e"))
+	assert.True(t, g.IsBlocked("c"))
+	assert.False(t, g.IsBlocked("a"))
+	assert.Equal(t, [][]string{{"x", "y", "z"}}, g.Cycles())
+	assert.Equal(t, [][]string{{"a", "b", "c"}, {"a", "e"}}, g.BlockedChains())
+
+	g.addBlock("c", "c")
+	assert.Equal(t, [][]string{{"c"}, {"x", "y", "z"}}, g.Cycles())
+	assert.Equal(t, [][]string{{"a", "b"}, {"a", "e"}}, g.BlockedChains())
+}
+
+// TestDependencyGraphDiamonds tests that blocked chains are the longest chains and
+// are found without following every path through diamond-shaped dependencies
+// 测试阻塞链为最长链, 且菱形依赖不会导致遍历所有路径
+func TestDependencyGraphDiamonds(t *testing.T) {
+	const diamonds = 40
+	var issues []models.Issue
+	add := func(id string) string {
+		issues = append(issues, models.Issue{ID: id})
+		return id
+	}
+	var edges [][2]string
+	top := add("s0")
+	for i := 1; i <= diamonds; i++ {
+		left, right, bottom := add(fmt.Sprintf("l%d", i)), add(fmt.Sprintf("r%d", i)), add(fmt.Sprintf("s%d", i))
+		edges = append(edges, [2]string{top, left}, [2]string{top, right}, [2]string{left, bottom}, [2]string{right, bottom})
+		top = bottom
+	}
+	// 捷径比经过菱形的链短
+	edges = append(edges, [2]string{"s0", top})
+
+	g := newDependencyGraph(issues, nil)
+	for _, edge := range edges {
+		g.addBlock(edge[0], edge[1])
+	}
+	chains := g.BlockedChains()
+	if assert.Len(t, chains, 1) {
+		assert.Len(t, chains[0], 2*diamonds+1)
+		assert.Equal(t, []string{"s0", "l1", "s1"}, chains[0][:3])
+		assert.Equal(t, top, chains[0][len(chains[0])-1])
+	}
+}
+
+// TestIssueRelationsServiceDependencyGraph tests building a dependency graph from the API
+// 测试通过 API 构建依赖图
+func TestIssueRelationsServiceDependencyGraph(t *testing.T) {
+	srv := planetest.NewServer()
+	defer srv.Close()
+	fx := srv.Seed()
+
+	c := client.NewClient(srv.APIKey)
+	c.SetBaseURL(srv.BaseURL())
+	s := NewIssueRelationsService(c)
+
+	a := srv.AddIssue(fx.WorkspaceSlug, fx.ProjectID, models.Issue{Name: "A"})
+	b := srv.AddIssue(fx.WorkspaceSlug, fx.ProjectID, models.Issue{Name: "B"})
+	_, err := s.Create(fx.WorkspaceSlug, fx.ProjectID, a.ID, models.RelationBlocking, []string{b.ID})
+	assert.NoError(t, err)
+	_, err = s.Create(fx.WorkspaceSlug, fx.ProjectID, fx.IssueID, models.RelationBlockedBy, []string{b.ID})
+	assert.NoError(t, err)
+	_, err = s.Create(fx.WorkspaceSlug, fx.ProjectID, fx.IssueID, models.RelationRelatesTo, []string{a.ID})
+	assert.NoError(t, err)
+
+	g, err := s.DependencyGraph(fx.WorkspaceSlug, fx.ProjectID)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "B", g.Issue(b.ID).Name)
+	assert.Equal(t, [][]string{{a.ID, b.ID, fx.IssueID}}, g.BlockedChains())
+	assert.Empty(t, g.Cycles())
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/GeekWorkCode/plane-api-go/client"
+	"github.com/GeekWorkCode/plane-api-go/models"
+)
+
+// ArchivedMode selects whether List returns archived issues
+type ArchivedMode int
+
+const (
+	// ExcludeArchived lists only live issues, as the API does
+	ExcludeArchived ArchivedMode = iota
+	// IncludeArchived lists live issues followed by archived ones
+	IncludeArchived
+	// OnlyArchived lists only archived issues
+	OnlyArchived
+)
+
+// Archive archives an issue
+func (s *IssuesService) Archive(workspaceSlug string, projectID string, issueID string) error {
+	return s.ArchiveWithContext(context.Background(), workspaceSlug, projectID, issueID)
+}
+
+// ArchiveWithContext archives an issue. Plane only archives issues in a state of the
+// completed or cancelled group and answers with 400 Bad Request otherwise.
+func (s *IssuesService) ArchiveWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string) error {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/archive/", workspaceSlug, projectID, issueID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(req, nil)
+	return err
+}
+
+// Unarchive restores an archived issue
+func (s *IssuesService) Unarchive(workspaceSlug string, projectID string, issueID string) error {
+	return s.UnarchiveWithContext(context.Background(), workspaceSlug, projectID, issueID)
+}
+
+// UnarchiveWithContext restores an archived issue
+func (s *IssuesService) UnarchiveWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string) error {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/archive/", workspaceSlug, projectID, issueID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(req, nil)
+	return err
+}
+
+// ListArchived returns the archived issues of a project, optionally filtered by opts
+func (s *IssuesService) ListArchived(workspaceSlug string, projectID string, opts ...*IssueListOptions) ([]models.Issue, error) {
+	return s.ListArchivedWithContext(context.Background(), workspaceSlug, projectID, opts...)
+}
+
+// ListArchivedWithContext returns the archived issues of a project, following pagination
+// until every page is fetched. Only the first of opts is used, and its Archived field is ignored.
+func (s *IssuesService) ListArchivedWithContext(ctx context.Context, workspaceSlug string, projectID string, opts ...*IssueListOptions) ([]models.Issue, error) {
+	o := &IssueListOptions{}
+	if len(opts) > 0 && opts[0] != nil {
+		*o = *opts[0]
+	}
+	o.Archived = OnlyArchived
+	return s.ListWithContext(ctx, workspaceSlug, projectID, o)
+}
+
+// ListArchivedPage returns a single page of the archived issues of a project
+func (s *IssuesService) ListArchivedPage(workspaceSlug string, projectID string, opts *IssueListOptions) (*models.PaginatedResponse[models.Issue], error) {
+	return s.ListArchivedPageWithContext(context.Background(), workspaceSlug, projectID, opts)
+}
+
+// ListArchivedPageWithContext returns a single page of the archived issues of a
+// project, filtered like ListArchived
+func (s *IssuesService) ListArchivedPageWithContext(ctx context.Context, workspaceSlug string, projectID string, opts *IssueListOptions) (*models.PaginatedResponse[models.Issue], error) {
+	o := &IssueListOptions{}
+	if opts != nil {
+		*o = *opts
+	}
+	o.Archived = OnlyArchived
+	return s.ListPageWithContext(ctx, workspaceSlug, projectID, o)
+}
+
+// CreateDraft creates a draft issue, which is not listed until it is published
+func (s *IssuesService) CreateDraft(workspaceSlug string, projectID string, createRequest *IssueCreateRequest) (*models.Issue, error) {
+	return s.CreateDraftWithContext(context.Background(), workspaceSlug, projectID, createRequest)
+}
+
+// CreateDraftWithContext creates a draft issue, which is not listed until it is
+// published. The request is copied, so it can be reused for live issues.
+func (s *IssuesService) CreateDraftWithContext(ctx context.Context, workspaceSlug string, projectID string, createRequest *IssueCreateRequest) (*models.Issue, error) {
+	draft := *createRequest
+	draft.IsDraft = true
+	return s.CreateWithContext(ctx, workspaceSlug, projectID, &draft)
+}
+
+// PublishDraft turns a draft issue into a live issue
+func (s *IssuesService) PublishDraft(workspaceSlug string, projectID string, issueID string) (*models.Issue, error) {
+	return s.PublishDraftWithContext(context.Background(), workspaceSlug, projectID, issueID)
+}
+
+// PublishDraftWithContext turns a draft issue into a live issue
+func (s *IssuesService) PublishDraftWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string) (*models.Issue, error) {
+	isDraft := false
+	return s.UpdateWithContext(ctx, workspaceSlug, projectID, issueID, &IssueUpdateRequest{IsDraft: &isDraft})
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/GeekWorkCode/plane-api-go/client"
+	"github.com/GeekWorkCode/plane-api-go/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func issueIDs(issues []models.Issue) []string {
+	ids := make([]string, len(issues))
+	for i := range issues {
+		ids[i] = issues[i].ID
+	}
+	return ids
+}
+
+// TestIssuesServiceArchive tests archiving, unarchiving and listing archived issues
+// 测试归档、取消归档以及列出已归档问题
+func TestIssuesServiceArchive(t *testing.T) {
+	env := newTestEnv(t)
+	if env.workspaceSlug == "" || env.projectID == "" {
+		t.Skip("Required environment variables not set")
+	}
+	ws, proj := env.workspaceSlug, env.projectID
+	s := NewIssuesService(env.client)
+
+	issue, err := s.Create(ws, proj, &IssueCreateRequest{Name: "Archive me"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer s.Delete(ws, proj, issue.ID)
+
+	// 只有已完成或已取消的问题可以归档
+	err = s.Archive(ws, proj, issue.ID)
+	assert.True(t, client.IsBadRequest(err))
+
+	_, err = s.Update(ws, proj, issue.ID, &IssueUpdateRequest{StateName: "Done"})
+	assert.NoError(t, err)
+	assert.NoError(t, s.Archive(ws, proj, issue.ID))
+
+	live, err := s.List(ws, proj)
+	assert.NoError(t, err)
+	assert.NotContains(t, issueIDs(live), issue.ID)
+
+	archived, err := s.ListArchived(ws, proj)
+	assert.NoError(t, err)
+	if assert.Contains(t, issueIDs(archived), issue.ID) {
+		for _, it := range archived {
+			assert.NotNil(t, it.ArchivedAt)
+		}
+	}
+
+	all, err := s.List(ws, proj, &IssueListOptions{Archived: IncludeArchived})
+	assert.NoError(t, err)
+	assert.Len(t, all, len(live)+len(archived))
+	assert.Contains(t, issueIDs(all), issue.ID)
+
+	// 游标只属于其中一个列表
+	_, err = s.List(ws, proj, &IssueListOptions{ListOptions: ListOptions{Cursor: "1:1:0"}, Archived: IncludeArchived})
+	assert.Error(t, err)
+
+	page, err := s.ListArchivedPage(ws, proj, &IssueListOptions{ListOptions: ListOptions{PerPage: 1}})
+	if assert.NoError(t, err) {
+		assert.Len(t, page.Results, 1)
+	}
+
+	assert.NoError(t, s.Unarchive(ws, proj, issue.ID))
+	live, err = s.List(ws, proj)
+	assert.NoError(t, err)
+	assert.Contains(t, issueIDs(live), issue.ID)
+}
+
+// TestIssuesServiceDrafts tests creating and publishing draft issues
+// 测试创建和发布草稿问题
+func TestIssuesServiceDrafts(t *testing.T) {
+	env := newTestEnv(t)
+	if env.workspaceSlug == "" || env.projectID == "" {
+		t.Skip("Required environment variables not set")
+	}
+	ws, proj := env.workspaceSlug, env.projectID
+	s := NewIssuesService(env.client)
+
+	request := &IssueCreateRequest{Name: "Draft issue"}
+	draft, err := s.CreateDraft(ws, proj, request)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer s.Delete(ws, proj, draft.ID)
+	assert.True(t, draft.IsDraft)
+
+	// 请求未被修改, 再次使用时创建的是普通问题
+	assert.False(t, request.IsDraft)
+	issue, err := s.Create(ws, proj, request)
+	if assert.NoError(t, err) {
+		defer s.Delete(ws, proj, issue.ID)
+		assert.False(t, issue.IsDraft)
+	}
+
+	live, err := s.List(ws, proj)
+	assert.NoError(t, err)
+	assert.NotContains(t, issueIDs(live), draft.ID)
+
+	published, err := s.PublishDraft(ws, proj, draft.ID)
+	if assert.NoError(t, err) {
+		assert.False(t, published.IsDraft)
+	}
+	live, err = s.List(ws, proj)
+	assert.NoError(t, err)
+	assert.Contains(t, issueIDs(live), draft.ID)
+}
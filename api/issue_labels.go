@@ -0,0 +1,194 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/GeekWorkCode/plane-api-go/models"
+)
+
+// labelParent returns the ID of the parent label, or "" for a top-level label
+func labelParent(label *models.Label) string {
+	if label.Parent == nil {
+		return ""
+	}
+	return *label.Parent
+}
+
+// labelPath returns the path of names of a label such as "area/backend".
+// The path stops before a label that already appears in it, so cyclic
+// parents do not loop forever.
+func labelPath(byID map[string]*models.Label, label *models.Label) string {
+	name := label.Name
+	visited := map[string]bool{label.ID: true}
+	for parent, ok := byID[labelParent(label)]; ok && !visited[parent.ID]; parent, ok = byID[labelParent(parent)] {
+		visited[parent.ID] = true
+		name = parent.Name + "/" + name
+	}
+	return name
+}
+
+// labelPaths returns the paths of the labels by ID
+func labelPaths(labels []models.Label) map[string]string {
+	byID := make(map[string]*models.Label, len(labels))
+	for i := range labels {
+		byID[labels[i].ID] = &labels[i]
+	}
+	paths := make(map[string]string, len(labels))
+	for i := range labels {
+		paths[labels[i].ID] = labelPath(byID, &labels[i])
+	}
+	return paths
+}
+
+// resolveLabel finds the ID of a label given by ID, name or path of names such as
+// "area/backend". Names are compared exactly first and then ignoring case and
+// whitespace. The full paths of the labels are matched before their names, so
+// "frontend" selects a top-level label named frontend rather than "area/frontend".
+// A ref matching several labels, such as "backend" for "area/backend" and
+// "team/backend", returns an AmbiguousNameError listing their paths.
+func resolveLabel(labels []models.Label, ref string) (string, error) {
+	for i := range labels {
+		if labels[i].ID == ref {
+			return labels[i].ID, nil
+		}
+	}
+	paths := labelPaths(labels)
+	candidate := func(l *models.Label) NameCandidate { return NameCandidate{ID: l.ID, Name: paths[l.ID]} }
+
+	for _, equal := range nameComparisons {
+		for _, matches := range []func(l *models.Label) bool{
+			func(l *models.Label) bool { return pathEqual(paths[l.ID], ref, equal) },
+			func(l *models.Label) bool { return equal(l.Name, ref) },
+		} {
+			var found []NameCandidate
+			for i := range labels {
+				if matches(&labels[i]) {
+					found = append(found, candidate(&labels[i]))
+				}
+			}
+			switch {
+			case len(found) == 1:
+				return found[0].ID, nil
+			case len(found) > 1:
+				return "", &AmbiguousNameError{Kind: kindLabels, Name: ref, Candidates: found}
+			}
+		}
+	}
+	suggestions := suggestNames(labels, ref,
+		func(l *models.Label) []string { return []string{paths[l.ID], l.Name} },
+		candidate)
+	return "", &NameNotFoundError{Kind: kindLabels, Name: ref, Suggestions: suggestions}
+}
+
+// pathEqual reports whether the label path equals ref, comparing the names
+// between slashes with equal
+func pathEqual(path string, ref string, equal func(a, b string) bool) bool {
+	got, want := strings.Split(path, "/"), strings.Split(ref, "/")
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if !equal(strings.TrimSpace(got[i]), strings.TrimSpace(want[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+// findLabelIDsByName 通过标签名称或路径查找标签ID
+func (s *IssuesService) findLabelIDsByName(ctx context.Context, workspaceSlug string, projectID string, labelNames []string) ([]string, error) {
+	labelIDs := make([]string, 0, len(labelNames))
+	for _, name := range labelNames {
+		labelID, err := s.resolver.LabelID(ctx, workspaceSlug, projectID, name)
+		if err != nil {
+			return nil, err
+		}
+		labelIDs = append(labelIDs, labelID)
+	}
+	return labelIDs, nil
+}
+
+// AddLabels adds labels to an issue, keeping its other labels.
+// Labels are given by ID, name or path such as "area/backend".
+func (s *IssuesService) AddLabels(workspaceSlug string, projectID string, issueID string, labels ...string) (*models.Issue, error) {
+	return s.AddLabelsWithContext(context.Background(), workspaceSlug, projectID, issueID, labels...)
+}
+
+// AddLabelsWithContext adds labels to an issue, keeping its other labels.
+// The labels of the issue are read and written back, so a concurrent change
+// of the labels of the same issue may be lost.
+func (s *IssuesService) AddLabelsWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, labels ...string) (*models.Issue, error) {
+	return s.changeLabels(ctx, workspaceSlug, projectID, issueID, labels, func(current []string, ids []string) []string {
+		for _, id := range ids {
+			if !containsString(current, id) {
+				current = append(current, id)
+			}
+		}
+		return current
+	})
+}
+
+// RemoveLabels removes labels from an issue, keeping its other labels.
+// Labels are given by ID, name or path such as "area/backend".
+func (s *IssuesService) RemoveLabels(workspaceSlug string, projectID string, issueID string, labels ...string) (*models.Issue, error) {
+	return s.RemoveLabelsWithContext(context.Background(), workspaceSlug, projectID, issueID, labels...)
+}
+
+// RemoveLabelsWithContext removes labels from an issue, keeping its other labels.
+// The labels of the issue are read and written back, so a concurrent change
+// of the labels of the same issue may be lost.
+func (s *IssuesService) RemoveLabelsWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, labels ...string) (*models.Issue, error) {
+	return s.changeLabels(ctx, workspaceSlug, projectID, issueID, labels, func(current []string, ids []string) []string {
+		kept := []string{}
+		for _, id := range current {
+			if !containsString(ids, id) {
+				kept = append(kept, id)
+			}
+		}
+		return kept
+	})
+}
+
+// changeLabels resolves labels, applies change to the current labels of an issue
+// and updates the issue if they changed
+func (s *IssuesService) changeLabels(ctx context.Context, workspaceSlug string, projectID string, issueID string, labels []string, change func(current []string, ids []string) []string) (*models.Issue, error) {
+	ids, err := s.findLabelIDsByName(ctx, workspaceSlug, projectID, labels)
+	if err != nil {
+		return nil, fmt.Errorf("查找标签失败: %w", err)
+	}
+	issue, err := s.GetWithContext(ctx, workspaceSlug, projectID, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("获取问题失败: %w", err)
+	}
+
+	current := append([]string(nil), issue.Labels...)
+	updated := change(current, ids)
+	if sameStrings(issue.Labels, updated) {
+		return issue, nil
+	}
+	return s.UpdateWithContext(ctx, workspaceSlug, projectID, issueID, &IssueUpdateRequest{Labels: &updated})
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// sameStrings reports whether a and b hold the same values in the same order
+func sameStrings(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/GeekWorkCode/plane-api-go/client"
+	"github.com/GeekWorkCode/plane-api-go/models"
+	"github.com/GeekWorkCode/plane-api-go/planetest"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestResolveLabel tests finding labels by ID, name and nested path
+// 测试通过ID、名称和嵌套路径查找标签
+func TestResolveLabel(t *testing.T) {
+	area, frontend, team := "area", "frontend", "team"
+	labels := []models.Label{
+		{ID: "area", Name: "area"},
+		{ID: "area-backend", Name: "backend", Parent: &area},
+		{ID: "area-frontend", Name: "frontend", Parent: &area},
+		{ID: "frontend", Name: "frontend"},
+		{ID: "frontend-ui", Name: "ui", Parent: &frontend},
+		{ID: "slash", Name: "needs/triage"},
+		{ID: "team", Name: "team"},
+		{ID: "team-backend", Name: "backend", Parent: &team},
+		{ID: "team-ops-1", Name: "ops", Parent: &team},
+		{ID: "team-ops-2", Name: "ops", Parent: &team},
+		{ID: "mobile", Name: "mobile", Parent: &area},
+	}
+
+	for ref, want := range map[string]string{
+		"area-backend":   "area-backend",  // ID
+		"mobile":         "mobile",        // 只有嵌套标签使用该名称
+		"frontend":       "frontend",      // 路径 "frontend" 只匹配顶级标签
+		"area/frontend":  "area-frontend", // 路径
+		"area / backend": "area-backend",
+		"frontend/ui":    "frontend-ui",
+		"needs/triage":   "slash", // 名称中包含斜杠
+	} {
+		id, err := resolveLabel(labels, ref)
+		assert.NoError(t, err, ref)
+		assert.Equal(t, want, id, ref)
+	}
+
+	for _, ref := range []string{"missing", "area/ui", "area/backend/extra"} {
+		_, err := resolveLabel(labels, ref)
+		assert.True(t, client.IsNotFound(err), ref)
+	}
+
+	// 多个标签使用同一名称或路径
+	for ref, paths := range map[string][]string{
+		"backend":  {"area/backend", "team/backend"},
+		"team/ops": {"team/ops", "team/ops"},
+		"OPS":      {"team/ops", "team/ops"},
+	} {
+		_, err := resolveLabel(labels, ref)
+		var ambiguous *AmbiguousNameError
+		if assert.True(t, errors.As(err, &ambiguous), ref) {
+			var got []string
+			for _, c := range ambiguous.Candidates {
+				got = append(got, c.Name)
+			}
+			assert.Equal(t, paths, got, ref)
+		}
+	}
+
+	_, err := resolveLabel(labels, "area/backnd")
+	var notFound *NameNotFoundError
+	if assert.True(t, errors.As(err, &notFound)) && assert.NotEmpty(t, notFound.Suggestions) {
+		assert.Equal(t, "area/backend", notFound.Suggestions[0].Name)
+	}
+
+	// 父标签形成循环时不会死循环
+	self, loopA, loopB := "self", "loop-a", "loop-b"
+	cyclic := []models.Label{
+		{ID: "self", Name: "self", Parent: &self},
+		{ID: "loop-a", Name: "a", Parent: &loopB},
+		{ID: "loop-b", Name: "b", Parent: &loopA},
+	}
+	for ref, want := range map[string]string{"self": "self", "b/a": "loop-a", "a/b": "loop-b"} {
+		id, err := resolveLabel(cyclic, ref)
+		assert.NoError(t, err, ref)
+		assert.Equal(t, want, id, ref)
+	}
+}
+
+// TestIssueLabels tests assigning labels to issues by name
+// 测试通过名称为问题分配标签
+func TestIssueLabels(t *testing.T) {
+	srv := planetest.NewServer()
+	defer srv.Close()
+	fx := srv.Seed()
+
+	area := srv.AddLabel(fx.WorkspaceSlug, fx.ProjectID, models.Label{Name: "area"})
+	backend := srv.AddLabel(fx.WorkspaceSlug, fx.ProjectID, models.Label{Name: "backend", Parent: &area.ID})
+	bug := srv.AddLabel(fx.WorkspaceSlug, fx.ProjectID, models.Label{Name: "bug"})
+	urgent := srv.AddLabel(fx.WorkspaceSlug, fx.ProjectID, models.Label{Name: "urgent"})
+
+	c := client.NewClient(srv.APIKey)
+	c.SetBaseURL(srv.BaseURL())
+	s := NewIssuesService(c)
+
+	issue, err := s.Create(fx.WorkspaceSlug, fx.ProjectID, &IssueCreateRequest{
+		Name:       "Labelled issue",
+		LabelNames: []string{"area/backend", "bug"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{backend.ID, bug.ID}, issue.Labels)
+
+	t.Run("Update", func(t *testing.T) {
+		updated, err := s.Update(fx.WorkspaceSlug, fx.ProjectID, issue.ID, &IssueUpdateRequest{LabelNames: []string{"bug"}})
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{bug.ID}, updated.Labels)
+		}
+	})
+
+	t.Run("AddLabels", func(t *testing.T) {
+		updated, err := s.AddLabels(fx.WorkspaceSlug, fx.ProjectID, issue.ID, "urgent", "bug", backend.ID)
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{bug.ID, urgent.ID, backend.ID}, updated.Labels)
+		}
+	})
+
+	t.Run("RemoveLabels", func(t *testing.T) {
+		updated, err := s.RemoveLabels(fx.WorkspaceSlug, fx.ProjectID, issue.ID, "bug", "area/backend")
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{urgent.ID}, updated.Labels)
+		}
+
+		updated, err = s.RemoveLabels(fx.WorkspaceSlug, fx.ProjectID, issue.ID, "urgent")
+		if assert.NoError(t, err) {
+			assert.Empty(t, updated.Labels)
+		}
+	})
+
+	t.Run("UnknownLabel", func(t *testing.T) {
+		_, err := s.AddLabels(fx.WorkspaceSlug, fx.ProjectID, issue.ID, "area/frontend")
+		assert.True(t, client.IsNotFound(err))
+	})
+}
@@ -0,0 +1,455 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+
+	"github.com/GeekWorkCode/plane-api-go/client"
+	"github.com/GeekWorkCode/plane-api-go/models"
+)
+
+// IssueMover moves issues between the projects of a workspace. Plane has no
+// endpoint for this, so the issue is copied into the target project together
+// with its comments, links, attachments and worklogs.
+type IssueMover struct {
+	resolver    *Resolver
+	issues      *IssuesService
+	comments    *CommentsService
+	links       *LinksService
+	attachments *AttachmentsService
+	worklogs    *WorklogsService
+	labels      *LabelsService
+	projects    *ProjectsService
+}
+
+// NewIssueMover creates a new issue mover
+func NewIssueMover(client *client.Client) *IssueMover {
+	m := &IssueMover{
+		issues:      NewIssuesService(client),
+		comments:    NewCommentsService(client),
+		links:       NewLinksService(client),
+		attachments: NewAttachmentsService(client),
+		worklogs:    NewWorklogsService(client),
+		labels:      NewLabelsService(client),
+		projects:    NewProjectsService(client),
+	}
+	m.SetResolver(NewResolver(client, DefaultResolverTTL))
+	return m
+}
+
+// SetResolver sets the resolver used to map states, labels and members by name
+func (m *IssueMover) SetResolver(resolver *Resolver) {
+	m.resolver = resolver
+	m.issues.SetResolver(resolver)
+	m.comments.SetResolver(resolver)
+	m.labels.SetResolver(resolver)
+}
+
+// MoveOptions configures how an issue is moved
+type MoveOptions struct {
+	// ArchiveSource archives the source issue after it was copied. An open source
+	// issue is first moved to a cancelled state, as Plane only archives closed issues.
+	ArchiveSource bool
+
+	// CreateMissingLabels creates labels missing in the target project instead of
+	// dropping them from the issue
+	CreateMissingLabels bool
+}
+
+// MoveResult describes a moved issue
+type MoveResult struct {
+	Source      *models.Issue // the source issue as it was before the move
+	Target      *models.Issue // the issue created in the target project
+	Comments    int           // number of copied comments
+	Links       int           // number of copied links
+	Attachments int           // number of copied attachments
+	Worklogs    int           // number of copied worklogs
+
+	// UnmappedLabels and UnmappedAssignees hold the IDs of the labels and
+	// assignees of the source issue that the target issue does not have
+	UnmappedLabels    []string
+	UnmappedAssignees []string
+
+	Archived bool // whether the source issue was archived
+}
+
+// Move copies an issue into another project of the same workspace
+func (m *IssueMover) Move(workspaceSlug string, sourceProjectID string, issueID string, targetProjectID string, opts *MoveOptions) (*MoveResult, error) {
+	return m.MoveWithContext(context.Background(), workspaceSlug, sourceProjectID, issueID, targetProjectID, opts)
+}
+
+// MoveWithContext copies an issue into another project of the same workspace.
+// The state and labels are mapped by name; a state missing in the target project
+// is replaced by the first state of the same group. Assignees who are not members
+// of the target project are dropped. Comments keep their author where possible,
+// while links and worklogs are created by the user of the API key. Both issues
+// get a comment naming the identifier of the other one.
+//
+// The source issue is left unchanged until everything is copied. If copying
+// fails, the partial result is returned with the error, and the target issue,
+// if created, is left in place.
+func (m *IssueMover) MoveWithContext(ctx context.Context, workspaceSlug string, sourceProjectID string, issueID string, targetProjectID string, opts *MoveOptions) (*MoveResult, error) {
+	if opts == nil {
+		opts = &MoveOptions{}
+	}
+	if sourceProjectID == targetProjectID {
+		return nil, errors.New("源项目和目标项目相同")
+	}
+
+	source, err := m.issues.GetWithContext(ctx, workspaceSlug, sourceProjectID, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("获取源问题失败: %w", err)
+	}
+	result := &MoveResult{Source: source}
+
+	sourceProject, err := m.projects.GetWithContext(ctx, workspaceSlug, sourceProjectID)
+	if err != nil {
+		return result, fmt.Errorf("获取源项目失败: %w", err)
+	}
+	targetProject, err := m.projects.GetWithContext(ctx, workspaceSlug, targetProjectID)
+	if err != nil {
+		return result, fmt.Errorf("获取目标项目失败: %w", err)
+	}
+
+	createRequest := &IssueCreateRequest{
+		Name:            source.Name,
+		DescriptionHTML: source.DescriptionHTML,
+		Priority:        source.Priority,
+		Point:           source.Point,
+		IsDraft:         source.IsDraft,
+	}
+	if source.DescriptionHTML == "" {
+		createRequest.Description = source.Description
+	}
+	if source.StartDate != nil {
+		createRequest.StartDate = *source.StartDate
+	}
+	if source.TargetDate != nil {
+		createRequest.TargetDate = *source.TargetDate
+	}
+	if createRequest.State, err = m.mapState(ctx, workspaceSlug, sourceProjectID, targetProjectID, source.State); err != nil {
+		return result, err
+	}
+	if createRequest.Labels, result.UnmappedLabels, err = m.mapLabels(ctx, workspaceSlug, sourceProjectID, targetProjectID, source.Labels, opts.CreateMissingLabels); err != nil {
+		return result, err
+	}
+	if createRequest.Assignees, result.UnmappedAssignees, err = m.mapAssignees(ctx, workspaceSlug, targetProjectID, source.Assignees); err != nil {
+		return result, err
+	}
+
+	target, err := m.issues.CreateWithContext(ctx, workspaceSlug, targetProjectID, createRequest)
+	if err != nil {
+		return result, fmt.Errorf("创建目标问题失败: %w", err)
+	}
+	result.Target = target
+
+	if err := m.copyComments(ctx, workspaceSlug, sourceProjectID, targetProjectID, source.ID, target.ID, result); err != nil {
+		return result, err
+	}
+	if err := m.copyLinks(ctx, workspaceSlug, sourceProjectID, targetProjectID, source.ID, target.ID, result); err != nil {
+		return result, err
+	}
+	if err := m.copyAttachments(ctx, workspaceSlug, sourceProjectID, targetProjectID, source.ID, target.ID, result); err != nil {
+		return result, err
+	}
+	if err := m.copyWorklogs(ctx, workspaceSlug, sourceProjectID, targetProjectID, source.ID, target.ID, result); err != nil {
+		return result, err
+	}
+
+	// 在两个问题上记录对方的标识符
+	sourceIdentifier := fmt.Sprintf("%s-%d", sourceProject.Identifier, source.SequenceID)
+	targetIdentifier := fmt.Sprintf("%s-%d", targetProject.Identifier, target.SequenceID)
+	if _, err := m.comments.CreateWithContext(ctx, workspaceSlug, targetProjectID, target.ID, &CommentRequest{
+		CommentHTML: fmt.Sprintf("<p>Moved from %s</p>", sourceIdentifier),
+	}); err != nil {
+		return result, fmt.Errorf("记录原问题标识符失败: %w", err)
+	}
+	if _, err := m.comments.CreateWithContext(ctx, workspaceSlug, sourceProjectID, source.ID, &CommentRequest{
+		CommentHTML: fmt.Sprintf("<p>Moved to %s</p>", targetIdentifier),
+	}); err != nil {
+		return result, fmt.Errorf("记录新问题标识符失败: %w", err)
+	}
+
+	if opts.ArchiveSource {
+		if err := m.archive(ctx, workspaceSlug, sourceProjectID, source); err != nil {
+			return result, err
+		}
+		result.Archived = true
+	}
+	return result, nil
+}
+
+// mapState returns the ID of the target state with the name of the source state,
+// or else of the first target state of the same group, or "" for the default state
+func (m *IssueMover) mapState(ctx context.Context, workspaceSlug string, sourceProjectID string, targetProjectID string, stateID string) (string, error) {
+	if stateID == "" {
+		return "", nil
+	}
+	sourceStates, err := m.resolver.States(ctx, workspaceSlug, sourceProjectID)
+	if err != nil {
+		return "", fmt.Errorf("获取源项目状态失败: %w", err)
+	}
+	targetStates, err := m.resolver.States(ctx, workspaceSlug, targetProjectID)
+	if err != nil {
+		return "", fmt.Errorf("获取目标项目状态失败: %w", err)
+	}
+
+	var sourceState *models.State
+	for i := range sourceStates {
+		if sourceStates[i].ID == stateID {
+			sourceState = &sourceStates[i]
+			break
+		}
+	}
+	if sourceState == nil {
+		return "", nil
+	}
+	for _, equal := range nameComparisons {
+		for i := range targetStates {
+			if equal(targetStates[i].Name, sourceState.Name) {
+				return targetStates[i].ID, nil
+			}
+		}
+	}
+	for i := range targetStates {
+		if sourceState.Group != "" && targetStates[i].Group == sourceState.Group {
+			return targetStates[i].ID, nil
+		}
+	}
+	return "", nil
+}
+
+// mapLabels maps source label IDs to target label IDs by name or path, and returns
+// the source labels that could not be mapped
+func (m *IssueMover) mapLabels(ctx context.Context, workspaceSlug string, sourceProjectID string, targetProjectID string, labelIDs []string, create bool) ([]string, []string, error) {
+	if len(labelIDs) == 0 {
+		return nil, nil, nil
+	}
+	sourceLabels, err := m.resolver.Labels(ctx, workspaceSlug, sourceProjectID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("获取源项目标签失败: %w", err)
+	}
+	byID := make(map[string]*models.Label, len(sourceLabels))
+	for i := range sourceLabels {
+		byID[sourceLabels[i].ID] = &sourceLabels[i]
+	}
+
+	mapped := map[string]string{}
+	visiting := map[string]bool{}
+	var mapLabel func(label *models.Label) (string, error)
+	mapLabel = func(label *models.Label) (string, error) {
+		if id, ok := mapped[label.ID]; ok {
+			return id, nil
+		}
+		if visiting[label.ID] {
+			return "", fmt.Errorf("标签 '%s' 的父标签形成循环", label.Name)
+		}
+		visiting[label.ID] = true
+		defer delete(visiting, label.ID)
+		// 父标签先映射, 再按 "父/子" 路径查找
+		parentID, ref := "", label.Name
+		if parent, ok := byID[labelParent(label)]; ok {
+			var err error
+			if parentID, err = mapLabel(parent); err != nil || parentID == "" {
+				return "", err
+			}
+			ref = labelPath(byID, label)
+		}
+		id, err := m.resolver.LabelID(ctx, workspaceSlug, targetProjectID, ref)
+		if errors.Is(err, client.ErrNotFound) && create {
+			createRequest := &LabelCreateRequest{Name: label.Name, Description: label.Description, Color: label.Color}
+			if parentID != "" {
+				createRequest.Parent = &parentID
+			}
+			created, createErr := m.labels.CreateWithContext(ctx, workspaceSlug, targetProjectID, createRequest)
+			if createErr != nil {
+				return "", fmt.Errorf("创建标签 '%s' 失败: %w", label.Name, createErr)
+			}
+			id, err = created.ID, nil
+		}
+		if errors.Is(err, client.ErrNotFound) {
+			id, err = "", nil
+		}
+		if err != nil {
+			return "", err
+		}
+		mapped[label.ID] = id
+		return id, nil
+	}
+
+	var ids, unmapped []string
+	for _, labelID := range labelIDs {
+		label, ok := byID[labelID]
+		if !ok {
+			unmapped = append(unmapped, labelID)
+			continue
+		}
+		id, err := mapLabel(label)
+		if err != nil {
+			return nil, nil, err
+		}
+		if id == "" {
+			unmapped = append(unmapped, labelID)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, unmapped, nil
+}
+
+// mapAssignees keeps the assignees who are members of the target project and
+// returns the others
+func (m *IssueMover) mapAssignees(ctx context.Context, workspaceSlug string, targetProjectID string, assignees []string) ([]string, []string, error) {
+	if len(assignees) == 0 {
+		return nil, nil, nil
+	}
+	members, err := m.resolver.Members(ctx, workspaceSlug, targetProjectID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("获取目标项目成员失败: %w", err)
+	}
+	var kept, dropped []string
+	for _, assignee := range assignees {
+		if isProjectMember(members, assignee) {
+			kept = append(kept, assignee)
+		} else {
+			dropped = append(dropped, assignee)
+		}
+	}
+	return kept, dropped, nil
+}
+
+func isProjectMember(members []models.Member, userID string) bool {
+	for i := range members {
+		if members[i].Member.ID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// copyComments copies the comments in the order they were written, keeping the
+// author if they are a member of the target project
+func (m *IssueMover) copyComments(ctx context.Context, workspaceSlug string, sourceProjectID string, targetProjectID string, sourceID string, targetID string, result *MoveResult) error {
+	comments, err := m.comments.ListWithContext(ctx, workspaceSlug, sourceProjectID, sourceID)
+	if err != nil {
+		return fmt.Errorf("获取评论失败: %w", err)
+	}
+	members, err := m.resolver.Members(ctx, workspaceSlug, targetProjectID)
+	if err != nil {
+		return fmt.Errorf("获取目标项目成员失败: %w", err)
+	}
+	sort.SliceStable(comments, func(i, j int) bool { return comments[i].CreatedAt.Before(comments[j].CreatedAt) })
+
+	for _, comment := range comments {
+		request := &CommentRequest{CommentHTML: comment.CommentHTML}
+		if isProjectMember(members, comment.CreatedBy) {
+			request.CreatedBy = comment.CreatedBy
+		}
+		if _, err := m.comments.CreateWithContext(ctx, workspaceSlug, targetProjectID, targetID, request); err != nil {
+			return fmt.Errorf("复制评论 %s 失败: %w", comment.ID, err)
+		}
+		result.Comments++
+	}
+	return nil
+}
+
+// copyLinks copies the links of the issue
+func (m *IssueMover) copyLinks(ctx context.Context, workspaceSlug string, sourceProjectID string, targetProjectID string, sourceID string, targetID string, result *MoveResult) error {
+	links, err := m.links.ListWithContext(ctx, workspaceSlug, sourceProjectID, sourceID)
+	if err != nil {
+		return fmt.Errorf("获取链接失败: %w", err)
+	}
+	for _, link := range links {
+		if _, err := m.links.CreateWithContext(ctx, workspaceSlug, targetProjectID, targetID, &LinkCreateRequest{Title: link.Title, URL: link.URL}); err != nil {
+			return fmt.Errorf("复制链接 %s 失败: %w", link.URL, err)
+		}
+		result.Links++
+	}
+	return nil
+}
+
+// copyAttachments downloads the uploaded attachments of the issue and uploads
+// them to the target issue. Each file is held in memory while it is copied.
+func (m *IssueMover) copyAttachments(ctx context.Context, workspaceSlug string, sourceProjectID string, targetProjectID string, sourceID string, targetID string, result *MoveResult) error {
+	attachments, err := m.attachments.ListWithContext(ctx, workspaceSlug, sourceProjectID, sourceID)
+	if err != nil {
+		return fmt.Errorf("获取附件失败: %w", err)
+	}
+	for _, attachment := range attachments {
+		if !attachment.IsUploaded || attachment.IsDeleted {
+			continue
+		}
+		name, _ := attachment.Attributes["name"].(string)
+		if name == "" {
+			name = path.Base(attachment.Asset)
+		}
+		contentType, _ := attachment.Attributes["type"].(string)
+
+		var content bytes.Buffer
+		if err := m.attachments.DownloadWithContext(ctx, workspaceSlug, sourceProjectID, sourceID, attachment.ID, &content); err != nil {
+			return fmt.Errorf("复制附件 %s 失败: %w", name, err)
+		}
+		if contentType == "" {
+			contentType = http.DetectContentType(content.Bytes())
+		}
+		size := int64(content.Len())
+		if _, err := m.attachments.UploadToIssueWithContext(ctx, workspaceSlug, targetProjectID, targetID, name, contentType, size, &content); err != nil {
+			return fmt.Errorf("复制附件 %s 失败: %w", name, err)
+		}
+		result.Attachments++
+	}
+	return nil
+}
+
+// copyWorklogs copies the worklogs of the issue. Plane logs them for the user of
+// the API key, so the original user is lost.
+func (m *IssueMover) copyWorklogs(ctx context.Context, workspaceSlug string, sourceProjectID string, targetProjectID string, sourceID string, targetID string, result *MoveResult) error {
+	worklogs, err := m.worklogs.ListWithContext(ctx, workspaceSlug, sourceProjectID, sourceID)
+	if err != nil {
+		return fmt.Errorf("获取工作日志失败: %w", err)
+	}
+	for _, worklog := range worklogs {
+		if _, err := m.worklogs.CreateWithContext(ctx, workspaceSlug, targetProjectID, targetID, &WorklogCreateRequest{
+			Description: worklog.Description,
+			Duration:    worklog.Duration,
+		}); err != nil {
+			return fmt.Errorf("复制工作日志 %s 失败: %w", worklog.ID, err)
+		}
+		result.Worklogs++
+	}
+	return nil
+}
+
+// archive archives the source issue, first moving an open issue to a cancelled state
+func (m *IssueMover) archive(ctx context.Context, workspaceSlug string, projectID string, issue *models.Issue) error {
+	states, err := m.resolver.States(ctx, workspaceSlug, projectID)
+	if err != nil {
+		return fmt.Errorf("获取源项目状态失败: %w", err)
+	}
+	closed, cancelled := false, ""
+	for i := range states {
+		if states[i].ID == issue.State {
+			closed = states[i].Group == models.StateGroupCompleted || states[i].Group == models.StateGroupCancelled
+		}
+		if cancelled == "" && states[i].Group == models.StateGroupCancelled {
+			cancelled = states[i].ID
+		}
+	}
+	if !closed {
+		if cancelled == "" {
+			return errors.New("归档源问题失败: 项目中没有已取消状态")
+		}
+		if _, err := m.issues.UpdateWithContext(ctx, workspaceSlug, projectID, issue.ID, &IssueUpdateRequest{State: cancelled}); err != nil {
+			return fmt.Errorf("取消源问题失败: %w", err)
+		}
+	}
+	if err := m.issues.ArchiveWithContext(ctx, workspaceSlug, projectID, issue.ID); err != nil {
+		return fmt.Errorf("归档源问题失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/GeekWorkCode/plane-api-go/client"
+	"github.com/GeekWorkCode/plane-api-go/models"
+	"github.com/GeekWorkCode/plane-api-go/planetest"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIssueMover tests moving an issue with its comments, links, attachments and worklogs
+// 测试将问题连同评论、链接、附件和工作日志移动到另一个项目
+func TestIssueMover(t *testing.T) {
+	srv := planetest.NewServer()
+	defer srv.Close()
+	fx := srv.Seed()
+	ws, proj := fx.WorkspaceSlug, fx.ProjectID
+	alice, bob := fx.Members[0], fx.Members[1]
+
+	web := srv.AddProject(ws, models.Project{Name: "Website", Identifier: "WEB"})
+	srv.AddMember(web.ID, alice)
+	webBug := srv.AddLabel(ws, web.ID, models.Label{Name: "Bug"})
+
+	review := srv.AddState(ws, proj, models.State{Name: "Review", Group: models.StateGroupStarted})
+	bug := srv.AddLabel(ws, proj, models.Label{Name: "bug", Color: "#ff0000"})
+	area := srv.AddLabel(ws, proj, models.Label{Name: "area"})
+	backend := srv.AddLabel(ws, proj, models.Label{Name: "backend", Parent: &area.ID})
+	targetDate := "2026-12-01"
+
+	c := client.NewClient(srv.APIKey)
+	c.SetBaseURL(srv.BaseURL())
+	issues := NewIssuesService(c)
+	comments := NewCommentsService(c)
+	attachments := NewAttachmentsService(c)
+	mover := NewIssueMover(c)
+
+	newSource := func(t *testing.T) models.Issue {
+		source := srv.AddIssue(ws, proj, models.Issue{
+			Name:            "Move me",
+			DescriptionHTML: "<p>Details</p>",
+			Priority:        "high",
+			State:           review.ID,
+			Labels:          []string{bug.ID, backend.ID},
+			Assignees:       []string{alice.ID, bob.ID},
+			TargetDate:      &targetDate,
+		})
+		_, err := comments.Create(ws, proj, source.ID, &CommentRequest{CommentHTML: "<p>first</p>", CreatedBy: alice.ID})
+		assert.NoError(t, err)
+		_, err = comments.Create(ws, proj, source.ID, &CommentRequest{CommentHTML: "<p>second</p>", CreatedBy: bob.ID})
+		assert.NoError(t, err)
+		_, err = NewLinksService(c).Create(ws, proj, source.ID, &LinkCreateRequest{Title: "Spec", URL: "https://example.com/spec"})
+		assert.NoError(t, err)
+		_, err = NewWorklogsService(c).Create(ws, proj, source.ID, &WorklogCreateRequest{Description: "triage", Duration: 30})
+		assert.NoError(t, err)
+		content := "log output"
+		_, err = attachments.UploadToIssue(ws, proj, source.ID, "log.txt", "text/plain", int64(len(content)), strings.NewReader(content))
+		assert.NoError(t, err)
+		return source
+	}
+
+	t.Run("Move", func(t *testing.T) {
+		source := newSource(t)
+		result, err := mover.Move(ws, proj, source.ID, web.ID, &MoveOptions{ArchiveSource: true})
+		if !assert.NoError(t, err) {
+			return
+		}
+		target := result.Target
+		assert.Equal(t, "Move me", target.Name)
+		assert.Equal(t, "<p>Details</p>", target.DescriptionHTML)
+		assert.Equal(t, "high", target.Priority)
+		if assert.NotNil(t, target.TargetDate) {
+			assert.Equal(t, targetDate, *target.TargetDate)
+		}
+
+		// 目标项目没有 "Review" 状态, 使用同组的第一个状态
+		state, err := NewStatesService(c).Get(ws, web.ID, target.State)
+		if assert.NoError(t, err) {
+			assert.Equal(t, models.StateGroupStarted, state.Group)
+		}
+		assert.Equal(t, []string{webBug.ID}, target.Labels)
+		assert.Equal(t, []string{backend.ID}, result.UnmappedLabels)
+		assert.Equal(t, []string{alice.ID}, target.Assignees)
+		assert.Equal(t, []string{bob.ID}, result.UnmappedAssignees)
+
+		assert.Equal(t, 2, result.Comments)
+		assert.Equal(t, 1, result.Links)
+		assert.Equal(t, 1, result.Attachments)
+		assert.Equal(t, 1, result.Worklogs)
+
+		copied, err := comments.List(ws, web.ID, target.ID)
+		if assert.NoError(t, err) && assert.Len(t, copied, 3) {
+			assert.Equal(t, "<p>first</p>", copied[0].CommentHTML)
+			assert.Equal(t, alice.ID, copied[0].CreatedBy)
+			assert.Equal(t, "<p>second</p>", copied[1].CommentHTML)
+			assert.Empty(t, copied[1].CreatedBy)
+			assert.Equal(t, fmt.Sprintf("<p>Moved from DEMO-%d</p>", source.SequenceID), copied[2].CommentHTML)
+		}
+
+		files, err := attachments.List(ws, web.ID, target.ID)
+		if assert.NoError(t, err) && assert.Len(t, files, 1) {
+			var content bytes.Buffer
+			assert.NoError(t, attachments.Download(ws, web.ID, target.ID, files[0].ID, &content))
+			assert.Equal(t, "log output", content.String())
+		}
+
+		assert.True(t, result.Archived)
+		archived, err := issues.ListArchived(ws, proj)
+		assert.NoError(t, err)
+		assert.Contains(t, issueIDs(archived), source.ID)
+	})
+
+	t.Run("CreateMissingLabels", func(t *testing.T) {
+		source := newSource(t)
+		result, err := mover.Move(ws, proj, source.ID, web.ID, &MoveOptions{CreateMissingLabels: true})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Empty(t, result.UnmappedLabels)
+		assert.False(t, result.Archived)
+
+		labelID, err := mover.resolver.LabelID(context.Background(), ws, web.ID, "area/backend")
+		if assert.NoError(t, err) {
+			assert.ElementsMatch(t, []string{webBug.ID, labelID}, result.Target.Labels)
+		}
+
+		live, err := issues.List(ws, proj)
+		assert.NoError(t, err)
+		assert.Contains(t, issueIDs(live), source.ID)
+	})
+
+	t.Run("SameProject", func(t *testing.T) {
+		_, err := mover.Move(ws, proj, fx.IssueID, proj, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("CyclicLabels", func(t *testing.T) {
+		loopA := srv.AddLabel(ws, proj, models.Label{Name: "loop-a"})
+		loopB := srv.AddLabel(ws, proj, models.Label{Name: "loop-b", Parent: &loopA.ID})
+		_, err := NewLabelsService(c).Update(ws, proj, loopA.ID, &LabelUpdateRequest{Parent: &loopB.ID})
+		if !assert.NoError(t, err) {
+			return
+		}
+		source := srv.AddIssue(ws, proj, models.Issue{Name: "Cyclic", Labels: []string{loopB.ID}})
+
+		_, err = NewIssueMover(c).Move(ws, proj, source.ID, web.ID, &MoveOptions{CreateMissingLabels: true})
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "循环")
+		}
+	})
+}
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/GeekWorkCode/plane-api-go/client"
+	"github.com/GeekWorkCode/plane-api-go/models"
+)
+
+// ErrInvalidIssueRef is returned when a string is neither an issue identifier nor an issue URL
+var ErrInvalidIssueRef = errors.New("plane: invalid issue reference")
+
+// IssueSearchOptions configures an issue search
+type IssueSearchOptions struct {
+	ProjectID string // limits the search to a project, searches the whole workspace if empty
+	Limit     int    // maximum number of results, 10 if zero
+}
+
+// Search searches the issues of a workspace by text
+func (s *IssuesService) Search(workspaceSlug string, query string, opts *IssueSearchOptions) ([]models.IssueSearchResult, error) {
+	return s.SearchWithContext(context.Background(), workspaceSlug, query, opts)
+}
+
+// SearchWithContext searches the issues of a workspace by text. The query matches
+// issue names and project identifiers containing it, and issues whose sequence
+// number is one of the numbers in it. Archived and draft issues are not found.
+func (s *IssuesService) SearchWithContext(ctx context.Context, workspaceSlug string, query string, opts *IssueSearchOptions) ([]models.IssueSearchResult, error) {
+	params := url.Values{}
+	params.Set("search", query)
+	params.Set("workspace_search", "true")
+	if opts != nil && opts.ProjectID != "" {
+		params.Set("workspace_search", "false")
+		params.Set("project_id", opts.ProjectID)
+	}
+	if opts != nil && opts.Limit > 0 {
+		params.Set("limit", strconv.Itoa(opts.Limit))
+	}
+
+	path := withQuery(client.Pathf("/workspaces/%s/issues/search/", workspaceSlug), params)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(models.IssueSearchResponse)
+	if _, err := s.client.Do(req, response); err != nil {
+		return nil, fmt.Errorf("搜索问题失败: %w", err)
+	}
+	if response.Issues == nil {
+		return []models.IssueSearchResult{}, nil
+	}
+	return response.Issues, nil
+}
+
+// IssueRef identifies an issue by an identifier such as "WEB-123" or by a URL
+// copied from the Plane UI
+type IssueRef struct {
+	WorkspaceSlug     string // set for URLs
+	ProjectID         string // set for URLs of issues in a project
+	IssueID           string // set for URLs of issues in a project
+	ProjectIdentifier string // e.g. "WEB", set for identifiers and browse URLs
+	SequenceID        int
+}
+
+// Identifier returns the identifier of the issue such as "WEB-123", or "" if the
+// reference has none
+func (r *IssueRef) Identifier() string {
+	if r.ProjectIdentifier == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s-%d", r.ProjectIdentifier, r.SequenceID)
+}
+
+// issueIdentifierPattern matches issue identifiers such as "WEB-123"
+var issueIdentifierPattern = regexp.MustCompile(`^([A-Za-z0-9]+)-([0-9]+)$`)
+
+// ParseIssueRef parses an issue identifier such as "WEB-123" or a URL copied from
+// the Plane UI, such as
+//
+//	https://app.plane.so/my-workspace/browse/WEB-123/
+//	https://app.plane.so/my-workspace/projects/<project-id>/issues/<issue-id>
+//	https://app.plane.so/my-workspace/projects/<project-id>/issues/?peekId=<issue-id>
+func ParseIssueRef(s string) (*IssueRef, error) {
+	s = strings.TrimSpace(s)
+	if ref, ok := parseIssueIdentifier(s); ok {
+		return ref, nil
+	}
+
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("无法解析问题引用 '%s': %w", s, ErrInvalidIssueRef)
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 1; i < len(segments); i++ {
+		workspace := segments[i-1]
+		switch segments[i] {
+		case "browse":
+			// /{workspace}/browse/WEB-123/
+			if i+1 < len(segments) {
+				if ref, ok := parseIssueIdentifier(segments[i+1]); ok {
+					ref.WorkspaceSlug = workspace
+					return ref, nil
+				}
+			}
+		case "projects":
+			// /{workspace}/projects/{project}/issues/{issue} or .../issues/?peekId={issue}
+			if i+2 >= len(segments) || (segments[i+2] != "issues" && segments[i+2] != "work-items") {
+				continue
+			}
+			ref := &IssueRef{WorkspaceSlug: workspace, ProjectID: segments[i+1]}
+			if i+3 < len(segments) {
+				ref.IssueID = segments[i+3]
+			} else {
+				ref.IssueID = u.Query().Get("peekId")
+			}
+			if ref.IssueID != "" {
+				return ref, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("无法解析问题引用 '%s': %w", s, ErrInvalidIssueRef)
+}
+
+func parseIssueIdentifier(s string) (*IssueRef, bool) {
+	match := issueIdentifierPattern.FindStringSubmatch(s)
+	if match == nil {
+		return nil, false
+	}
+	sequenceID, err := strconv.Atoi(match[2])
+	if err != nil {
+		return nil, false
+	}
+	return &IssueRef{ProjectIdentifier: strings.ToUpper(match[1]), SequenceID: sequenceID}, true
+}
+
+// GetByRef returns the issue an identifier such as "WEB-123" or a Plane URL refers to
+func (s *IssuesService) GetByRef(workspaceSlug string, ref string) (*models.Issue, error) {
+	return s.GetByRefWithContext(context.Background(), workspaceSlug, ref)
+}
+
+// GetByRefWithContext returns the issue an identifier such as "WEB-123" or a Plane URL
+// refers to. The workspace of a URL takes precedence over workspaceSlug.
+func (s *IssuesService) GetByRefWithContext(ctx context.Context, workspaceSlug string, ref string) (*models.Issue, error) {
+	issueRef, err := ParseIssueRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	if issueRef.WorkspaceSlug != "" {
+		workspaceSlug = issueRef.WorkspaceSlug
+	}
+	if issueRef.IssueID != "" {
+		return s.GetWithContext(ctx, workspaceSlug, issueRef.ProjectID, issueRef.IssueID)
+	}
+	return s.GetBySequenceIDWithContext(ctx, workspaceSlug, issueRef.Identifier())
+}
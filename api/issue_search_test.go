@@ -0,0 +1,101 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/GeekWorkCode/plane-api-go/client"
+	"github.com/GeekWorkCode/plane-api-go/models"
+	"github.com/GeekWorkCode/plane-api-go/planetest"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseIssueRef tests parsing issue identifiers and URLs
+// 测试解析问题标识符和链接
+func TestParseIssueRef(t *testing.T) {
+	tests := []struct {
+		in   string
+		want IssueRef
+	}{
+		{"WEB-123", IssueRef{ProjectIdentifier: "WEB", SequenceID: 123}},
+		{" web-7 ", IssueRef{ProjectIdentifier: "WEB", SequenceID: 7}},
+		{"https://app.plane.so/acme/browse/WEB-123/", IssueRef{WorkspaceSlug: "acme", ProjectIdentifier: "WEB", SequenceID: 123}},
+		{"https://app.plane.so/acme/projects/p1/issues/i1", IssueRef{WorkspaceSlug: "acme", ProjectID: "p1", IssueID: "i1"}},
+		{"https://app.plane.so/acme/projects/p1/work-items/i1/", IssueRef{WorkspaceSlug: "acme", ProjectID: "p1", IssueID: "i1"}},
+		{"https://app.plane.so/acme/projects/p1/issues/?peekId=i1", IssueRef{WorkspaceSlug: "acme", ProjectID: "p1", IssueID: "i1"}},
+		{"https://plane.example.com/plane/acme/browse/OPS-1", IssueRef{WorkspaceSlug: "acme", ProjectIdentifier: "OPS", SequenceID: 1}},
+	}
+	for _, tt := range tests {
+		ref, err := ParseIssueRef(tt.in)
+		if assert.NoError(t, err, tt.in) {
+			assert.Equal(t, tt.want, *ref, tt.in)
+		}
+	}
+
+	for _, in := range []string{"", "WEB", "WEB-", "123", "https://app.plane.so/acme/projects/p1/issues/", "acme/browse/WEB-1"} {
+		_, err := ParseIssueRef(in)
+		assert.True(t, errors.Is(err, ErrInvalidIssueRef), in)
+	}
+}
+
+// TestIssuesServiceSearch tests searching issues across the projects of a workspace
+// 测试跨项目搜索问题
+func TestIssuesServiceSearch(t *testing.T) {
+	srv := planetest.NewServer()
+	defer srv.Close()
+	fx := srv.Seed()
+	web := srv.AddProject(fx.WorkspaceSlug, models.Project{Name: "Website", Identifier: "WEB"})
+	login := srv.AddIssue(fx.WorkspaceSlug, web.ID, models.Issue{Name: "Fix login redirect"})
+	srv.AddIssue(fx.WorkspaceSlug, fx.ProjectID, models.Issue{Name: "Login page copy"})
+
+	c := client.NewClient(srv.APIKey)
+	c.SetBaseURL(srv.BaseURL())
+	issues := NewIssuesService(c)
+
+	t.Run("Workspace", func(t *testing.T) {
+		results, err := issues.Search(fx.WorkspaceSlug, "login", nil)
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+	})
+
+	t.Run("Project", func(t *testing.T) {
+		results, err := issues.Search(fx.WorkspaceSlug, "LOGIN", &IssueSearchOptions{ProjectID: web.ID})
+		if assert.NoError(t, err) && assert.Len(t, results, 1) {
+			assert.Equal(t, login.ID, results[0].ID)
+			assert.Equal(t, "WEB-1", results[0].Identifier())
+		}
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		results, err := issues.Search(fx.WorkspaceSlug, "login", &IssueSearchOptions{Limit: 1})
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+	})
+
+	t.Run("NoMatch", func(t *testing.T) {
+		results, err := issues.Search(fx.WorkspaceSlug, "nothing like this", nil)
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+		assert.NotNil(t, results)
+	})
+
+	t.Run("GetByRef", func(t *testing.T) {
+		for _, ref := range []string{
+			"WEB-1",
+			"web-1",
+			"https://app.plane.so/" + fx.WorkspaceSlug + "/browse/WEB-1/",
+			"https://app.plane.so/" + fx.WorkspaceSlug + "/projects/" + web.ID + "/issues/" + login.ID,
+			"https://app.plane.so/" + fx.WorkspaceSlug + "/projects/" + web.ID + "/issues/?peekId=" + login.ID,
+		} {
+			issue, err := issues.GetByRef(fx.WorkspaceSlug, ref)
+			if assert.NoError(t, err, ref) {
+				assert.Equal(t, login.ID, issue.ID, ref)
+			}
+		}
+
+		_, err := issues.GetByRef(fx.WorkspaceSlug, "WEB-99")
+		assert.True(t, client.IsNotFound(err))
+		_, err = issues.GetByRef(fx.WorkspaceSlug, "not an issue")
+		assert.True(t, errors.Is(err, ErrInvalidIssueRef))
+	})
+}
@@ -0,0 +1,255 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"os"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// IssueTemplate describes an issue, and optionally its sub-issues, to create
+// from variables. All string fields are Go text/template templates, e.g.
+//
+//	name: "Incident: {{.service}} is down"
+//	description_html: "<p>Reported by {{.reporter | html}}</p>"
+//	state: Todo
+//	labels: [incident, "severity/{{.severity}}"]
+//	assignees: ["{{.oncall}}"]
+//	checklist: [Acknowledge, Post status update]
+//	sub_issues:
+//	  - name: "Postmortem for {{.service}}"
+//
+// Values are inserted into description_html as they are; use the html
+// function to escape them.
+type IssueTemplate struct {
+	Name            string          `yaml:"name" json:"name"`
+	DescriptionHTML string          `yaml:"description_html" json:"description_html"`
+	State           string          `yaml:"state" json:"state"` // 状态名称
+	Priority        string          `yaml:"priority" json:"priority"`
+	Labels          []string        `yaml:"labels" json:"labels"`       // 标签名称或路径如 "area/backend"
+	Assignees       []string        `yaml:"assignees" json:"assignees"` // 成员名称或邮箱
+	StartDate       string          `yaml:"start_date" json:"start_date"`
+	TargetDate      string          `yaml:"target_date" json:"target_date"`
+	Checklist       []string        `yaml:"checklist" json:"checklist"` // appended to the description as a task list
+	SubIssues       []IssueTemplate `yaml:"sub_issues" json:"sub_issues"`
+}
+
+// ParseIssueTemplate parses an issue template in YAML or JSON. Unknown fields are errors.
+func ParseIssueTemplate(data []byte) (*IssueTemplate, error) {
+	tmpl := new(IssueTemplate)
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		decoder := json.NewDecoder(bytes.NewReader(trimmed))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(tmpl); err != nil {
+			return nil, fmt.Errorf("解析 JSON 模板失败: %w", err)
+		}
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(tmpl); err != nil {
+			return nil, fmt.Errorf("解析 YAML 模板失败: %w", err)
+		}
+	}
+	if err := tmpl.validate("issue"); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// LoadIssueTemplate reads an issue template from a YAML or JSON file
+func LoadIssueTemplate(path string) (*IssueTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取模板文件失败: %w", err)
+	}
+	return ParseIssueTemplate(data)
+}
+
+// validate checks that every issue of the template has a name
+func (t *IssueTemplate) validate(path string) error {
+	if strings.TrimSpace(t.Name) == "" {
+		return fmt.Errorf("模板 %s 缺少名称", path)
+	}
+	for i := range t.SubIssues {
+		if err := t.SubIssues[i].validate(fmt.Sprintf("%s.sub_issues[%d]", path, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Render executes the templates of all fields with vars and returns the result.
+// A variable missing from vars is an error. Labels and assignees that render
+// to an empty string are dropped, so they can depend on optional variables
+// via {{if}}.
+func (t *IssueTemplate) Render(vars map[string]interface{}) (*IssueTemplate, error) {
+	return t.render("issue", vars)
+}
+
+func (t *IssueTemplate) render(path string, vars map[string]interface{}) (*IssueTemplate, error) {
+	r := &templateRenderer{path: path, vars: vars}
+	out := &IssueTemplate{
+		Name:            r.field("name", t.Name),
+		DescriptionHTML: r.field("description_html", t.DescriptionHTML),
+		State:           r.field("state", t.State),
+		Priority:        r.field("priority", t.Priority),
+		Labels:          r.list("labels", t.Labels),
+		Assignees:       r.list("assignees", t.Assignees),
+		StartDate:       r.field("start_date", t.StartDate),
+		TargetDate:      r.field("target_date", t.TargetDate),
+		Checklist:       r.list("checklist", t.Checklist),
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	if strings.TrimSpace(out.Name) == "" {
+		return nil, fmt.Errorf("模板 %s 的名称渲染结果为空", path)
+	}
+	for i := range t.SubIssues {
+		sub, err := t.SubIssues[i].render(fmt.Sprintf("%s.sub_issues[%d]", path, i), vars)
+		if err != nil {
+			return nil, err
+		}
+		out.SubIssues = append(out.SubIssues, *sub)
+	}
+	return out, nil
+}
+
+// templateRenderer renders the fields of one template, keeping the first error
+type templateRenderer struct {
+	path string
+	vars map[string]interface{}
+	err  error
+}
+
+func (r *templateRenderer) field(name string, text string) string {
+	if r.err != nil || !strings.Contains(text, "{{") {
+		return text
+	}
+	name = r.path + "." + name
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		r.err = fmt.Errorf("解析模板字段 %s 失败: %w", name, err)
+		return ""
+	}
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, r.vars); err != nil {
+		r.err = fmt.Errorf("渲染模板字段 %s 失败: %w", name, err)
+		return ""
+	}
+	return buf.String()
+}
+
+func (r *templateRenderer) list(name string, items []string) []string {
+	var out []string
+	for i, item := range items {
+		if value := strings.TrimSpace(r.field(fmt.Sprintf("%s[%d]", name, i), item)); value != "" {
+			out = append(out, value)
+		}
+	}
+	return out
+}
+
+// createRequest returns the create request of a rendered template, with the
+// checklist appended to the description as a task list
+func (t *IssueTemplate) createRequest() *IssueCreateRequest {
+	description := t.DescriptionHTML
+	if len(t.Checklist) > 0 {
+		var b strings.Builder
+		b.WriteString(`<ul data-type="taskList">`)
+		for _, item := range t.Checklist {
+			fmt.Fprintf(&b, `<li data-type="taskItem" data-checked="false"><p>%s</p></li>`, html.EscapeString(item))
+		}
+		b.WriteString(`</ul>`)
+		description += b.String()
+	}
+	return &IssueCreateRequest{
+		Name:            t.Name,
+		DescriptionHTML: description,
+		StateName:       t.State,
+		Priority:        t.Priority,
+		LabelNames:      t.Labels,
+		AssigneeNames:   t.Assignees,
+		StartDate:       t.StartDate,
+		TargetDate:      t.TargetDate,
+	}
+}
+
+// templateRequest is a create request together with those of its sub-issues
+type templateRequest struct {
+	request   *IssueCreateRequest
+	subIssues []*templateRequest
+}
+
+// CreateFromTemplate renders a template with vars and creates the issue and its sub-issues
+func (s *IssuesService) CreateFromTemplate(workspaceSlug string, projectID string, tmpl *IssueTemplate, vars map[string]interface{}) (*IssueNode, error) {
+	return s.CreateFromTemplateWithContext(context.Background(), workspaceSlug, projectID, tmpl, vars)
+}
+
+// CreateFromTemplateWithContext renders a template with vars and creates the issue
+// and its sub-issues. The states, labels and assignees of all issues are resolved
+// before the first issue is created, so a template referring to an unknown name
+// creates nothing. If creating an issue fails, the issues created so far are
+// returned with the error.
+func (s *IssuesService) CreateFromTemplateWithContext(ctx context.Context, workspaceSlug string, projectID string, tmpl *IssueTemplate, vars map[string]interface{}) (*IssueNode, error) {
+	rendered, err := tmpl.Render(vars)
+	if err != nil {
+		return nil, err
+	}
+	root, err := s.resolveTemplate(ctx, workspaceSlug, projectID, rendered)
+	if err != nil {
+		return nil, err
+	}
+
+	var create func(r *templateRequest, parent *IssueNode) (*IssueNode, error)
+	create = func(r *templateRequest, parent *IssueNode) (*IssueNode, error) {
+		if parent != nil {
+			r.request.Parent = parent.Issue.ID
+		}
+		issue, err := s.CreateWithContext(ctx, workspaceSlug, projectID, r.request)
+		if err != nil {
+			return nil, fmt.Errorf("创建问题 '%s' 失败: %w", r.request.Name, err)
+		}
+		node := &IssueNode{Issue: *issue, Parent: parent}
+		if parent != nil {
+			node.Depth = parent.Depth + 1
+			parent.Children = append(parent.Children, node)
+		}
+		for _, sub := range r.subIssues {
+			if _, err := create(sub, node); err != nil {
+				return node, err
+			}
+		}
+		return node, nil
+	}
+	node, err := create(root, nil)
+	if node != nil {
+		// 返回根节点, 即使部分子问题创建失败
+		for node.Parent != nil {
+			node = node.Parent
+		}
+	}
+	return node, err
+}
+
+// resolveTemplate builds the create requests of a rendered template, resolving all names
+func (s *IssuesService) resolveTemplate(ctx context.Context, workspaceSlug string, projectID string, t *IssueTemplate) (*templateRequest, error) {
+	r := &templateRequest{request: t.createRequest()}
+	if err := s.resolveCreateNames(ctx, workspaceSlug, projectID, r.request); err != nil {
+		return nil, fmt.Errorf("模板问题 '%s': %w", t.Name, err)
+	}
+	for i := range t.SubIssues {
+		sub, err := s.resolveTemplate(ctx, workspaceSlug, projectID, &t.SubIssues[i])
+		if err != nil {
+			return nil, err
+		}
+		r.subIssues = append(r.subIssues, sub)
+	}
+	return r, nil
+}
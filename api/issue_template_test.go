@@ -0,0 +1,160 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GeekWorkCode/plane-api-go/client"
+	"github.com/GeekWorkCode/plane-api-go/models"
+	"github.com/GeekWorkCode/plane-api-go/planetest"
+	"github.com/stretchr/testify/assert"
+)
+
+const incidentTemplate = `
+name: "Incident: {{.service}} is down"
+description_html: "<p>Reported by {{.reporter | html}}</p>"
+state: Todo
+priority: urgent
+labels: [incident, "{{if .customer}}customer{{end}}"]
+assignees: ["{{.oncall}}"]
+checklist:
+  - Acknowledge
+  - Update <status page>
+sub_issues:
+  - name: "Postmortem for {{.service}}"
+    state: Backlog
+    assignees: [bob]
+`
+
+// TestParseIssueTemplate tests parsing templates in YAML and JSON
+// 测试解析 YAML 和 JSON 格式的模板
+func TestParseIssueTemplate(t *testing.T) {
+	tmpl, err := ParseIssueTemplate([]byte(incidentTemplate))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Todo", tmpl.State)
+		assert.Len(t, tmpl.Checklist, 2)
+		if assert.Len(t, tmpl.SubIssues, 1) {
+			assert.Equal(t, []string{"bob"}, tmpl.SubIssues[0].Assignees)
+		}
+	}
+
+	tmpl, err = ParseIssueTemplate([]byte(`{"name": "Deploy {{.version}}", "labels": ["release"], "sub_issues": [{"name": "Smoke test"}]}`))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Deploy {{.version}}", tmpl.Name)
+		assert.Equal(t, []string{"release"}, tmpl.Labels)
+		assert.Len(t, tmpl.SubIssues, 1)
+	}
+
+	path := filepath.Join(t.TempDir(), "incident.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(incidentTemplate), 0644))
+	_, err = LoadIssueTemplate(path)
+	assert.NoError(t, err)
+
+	for _, data := range []string{
+		"name: x\nlabel: [bug]", // 未知字段
+		`{"name": "x", "lable": []}`,
+		"description_html: no name",
+		"name: x\nsub_issues:\n  - state: Todo",
+		"name: [",
+	} {
+		_, err := ParseIssueTemplate([]byte(data))
+		assert.Error(t, err, data)
+	}
+}
+
+// TestIssueTemplateRender tests rendering templates with variables
+// 测试使用变量渲染模板
+func TestIssueTemplateRender(t *testing.T) {
+	tmpl, err := ParseIssueTemplate([]byte(incidentTemplate))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	rendered, err := tmpl.Render(map[string]interface{}{
+		"service":  "api",
+		"reporter": "<Alice>",
+		"oncall":   "alice",
+		"customer": false,
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Incident: api is down", rendered.Name)
+		assert.Equal(t, "<p>Reported by &lt;Alice&gt;</p>", rendered.DescriptionHTML)
+		assert.Equal(t, []string{"incident"}, rendered.Labels)
+		assert.Equal(t, []string{"alice"}, rendered.Assignees)
+		assert.Equal(t, "Postmortem for api", rendered.SubIssues[0].Name)
+	}
+	// 原模板保持不变
+	assert.Equal(t, "Incident: {{.service}} is down", tmpl.Name)
+
+	_, err = tmpl.Render(map[string]interface{}{"service": "api"})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "issue.description_html")
+	}
+}
+
+// TestIssuesServiceCreateFromTemplate tests creating an issue with sub-issues from a template
+// 测试通过模板创建问题及其子问题
+func TestIssuesServiceCreateFromTemplate(t *testing.T) {
+	srv := planetest.NewServer()
+	defer srv.Close()
+	fx := srv.Seed()
+	ws, proj := fx.WorkspaceSlug, fx.ProjectID
+	incident := srv.AddLabel(ws, proj, models.Label{Name: "incident"})
+	customer := srv.AddLabel(ws, proj, models.Label{Name: "customer"})
+
+	c := client.NewClient(srv.APIKey)
+	c.SetBaseURL(srv.BaseURL())
+	s := NewIssuesService(c)
+
+	tmpl, err := ParseIssueTemplate([]byte(incidentTemplate))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		root, err := s.CreateFromTemplate(ws, proj, tmpl, map[string]interface{}{
+			"service":  "api",
+			"reporter": "Alice",
+			"oncall":   "alice@example.com",
+			"customer": true,
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+		issue := root.Issue
+		assert.Equal(t, "Incident: api is down", issue.Name)
+		assert.Equal(t, "urgent", issue.Priority)
+		assert.Equal(t, []string{incident.ID, customer.ID}, issue.Labels)
+		assert.Equal(t, []string{fx.Members[0].ID}, issue.Assignees)
+		assert.Contains(t, issue.DescriptionHTML, `<ul data-type="taskList">`)
+		assert.Contains(t, issue.DescriptionHTML, `<p>Update &lt;status page&gt;</p>`)
+
+		if assert.Len(t, root.Children, 1) {
+			sub := root.Children[0]
+			assert.Equal(t, "Postmortem for api", sub.Issue.Name)
+			assert.Equal(t, 1, sub.Depth)
+			if assert.NotNil(t, sub.Issue.Parent) {
+				assert.Equal(t, issue.ID, *sub.Issue.Parent)
+			}
+			assert.Equal(t, []string{fx.Members[1].ID}, sub.Issue.Assignees)
+		}
+	})
+
+	t.Run("UnknownName", func(t *testing.T) {
+		before, err := s.List(ws, proj)
+		assert.NoError(t, err)
+
+		bad := *tmpl
+		bad.SubIssues = []IssueTemplate{{Name: "Follow-up", State: "Nonexistent"}}
+		_, err = s.CreateFromTemplate(ws, proj, &bad, map[string]interface{}{
+			"service": "api", "reporter": "Alice", "oncall": "alice", "customer": false,
+		})
+		assert.True(t, client.IsNotFound(err))
+
+		// 名称解析失败时不创建任何问题
+		after, err := s.List(ws, proj)
+		assert.NoError(t, err)
+		assert.Len(t, after, len(before))
+	})
+}
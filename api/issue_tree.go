@@ -0,0 +1,155 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/GeekWorkCode/plane-api-go/models"
+)
+
+// ErrIssueCycle is returned by GetTree when the parent links of the issues form a cycle
+var ErrIssueCycle = errors.New("plane: issue hierarchy contains a cycle")
+
+// treeBatchSize is the number of parents whose sub-issues are fetched in one list request
+const treeBatchSize = 50
+
+// IssueNode is an issue in an issue tree together with its sub-issues
+// 问题树中的节点
+type IssueNode struct {
+	Issue    models.Issue
+	Parent   *IssueNode // nil for the root
+	Children []*IssueNode
+	Depth    int // 0 for the root
+}
+
+// Walk calls fn for the node and its descendants in depth-first order.
+// The children of a node are skipped when fn returns false.
+func (n *IssueNode) Walk(fn func(node *IssueNode) bool) {
+	if !fn(n) {
+		return
+	}
+	for _, child := range n.Children {
+		child.Walk(fn)
+	}
+}
+
+// Find returns the node of the issue with the given ID, or nil if it is not in the tree
+func (n *IssueNode) Find(issueID string) *IssueNode {
+	var found *IssueNode
+	n.Walk(func(node *IssueNode) bool {
+		if node.Issue.ID == issueID {
+			found = node
+		}
+		return found == nil
+	})
+	return found
+}
+
+// Len returns the number of issues in the tree, including the root
+func (n *IssueNode) Len() int {
+	count := 0
+	n.Walk(func(*IssueNode) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+// IsLeaf reports whether the node has no sub-issues.
+// Nodes at the depth limit of GetTree are leaves even if they have sub-issues.
+func (n *IssueNode) IsLeaf() bool {
+	return len(n.Children) == 0
+}
+
+// IssueTreeOptions configures GetTree
+type IssueTreeOptions struct {
+	// MaxDepth is the number of sub-issue levels fetched below the root.
+	// Zero fetches all levels.
+	MaxDepth int
+}
+
+// ListSubIssues returns the direct sub-issues of an issue
+func (s *IssuesService) ListSubIssues(workspaceSlug string, projectID string, issueID string) ([]models.Issue, error) {
+	return s.ListSubIssuesWithContext(context.Background(), workspaceSlug, projectID, issueID)
+}
+
+// ListSubIssuesWithContext returns the direct sub-issues of an issue. The parent
+// filter is also applied to the results, in case the server ignores it.
+func (s *IssuesService) ListSubIssuesWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string) ([]models.Issue, error) {
+	issues, err := s.ListWithContext(ctx, workspaceSlug, projectID, &IssueListOptions{Parents: []string{issueID}})
+	if err != nil {
+		return nil, err
+	}
+	subIssues := []models.Issue{}
+	for _, issue := range issues {
+		if issue.Parent != nil && *issue.Parent == issueID {
+			subIssues = append(subIssues, issue)
+		}
+	}
+	return subIssues, nil
+}
+
+// GetTree returns an issue with its sub-issues, recursively
+func (s *IssuesService) GetTree(workspaceSlug string, projectID string, issueID string, opts *IssueTreeOptions) (*IssueNode, error) {
+	return s.GetTreeWithContext(context.Background(), workspaceSlug, projectID, issueID, opts)
+}
+
+// GetTreeWithContext returns an issue with its sub-issues, recursively.
+// Sub-issues are fetched one level at a time, so the number of requests grows
+// with the depth of the tree rather than the number of issues.
+// An error wrapping ErrIssueCycle is returned if an issue is reached twice.
+func (s *IssuesService) GetTreeWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, opts *IssueTreeOptions) (*IssueNode, error) {
+	maxDepth := 0
+	if opts != nil {
+		maxDepth = opts.MaxDepth
+	}
+
+	root, err := s.GetWithContext(ctx, workspaceSlug, projectID, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("获取根问题失败: %w", err)
+	}
+	rootNode := &IssueNode{Issue: *root}
+	nodes := map[string]*IssueNode{root.ID: rootNode}
+
+	level := []*IssueNode{rootNode}
+	for depth := 1; len(level) > 0 && (maxDepth <= 0 || depth <= maxDepth); depth++ {
+		var next []*IssueNode
+		for start := 0; start < len(level); start += treeBatchSize {
+			end := start + treeBatchSize
+			if end > len(level) {
+				end = len(level)
+			}
+			parentIDs := make([]string, 0, end-start)
+			batch := map[string]*IssueNode{}
+			for _, node := range level[start:end] {
+				parentIDs = append(parentIDs, node.Issue.ID)
+				batch[node.Issue.ID] = node
+			}
+
+			children, err := s.ListWithContext(ctx, workspaceSlug, projectID, &IssueListOptions{Parents: parentIDs})
+			if err != nil {
+				return nil, fmt.Errorf("获取子问题失败: %w", err)
+			}
+			for _, child := range children {
+				if child.Parent == nil {
+					continue
+				}
+				// 只接受本批父问题的子问题, 以防服务器忽略过滤条件
+				parent, ok := batch[*child.Parent]
+				if !ok {
+					continue
+				}
+				if _, seen := nodes[child.ID]; seen {
+					return nil, fmt.Errorf("问题 %s 在层级中出现多次: %w", child.ID, ErrIssueCycle)
+				}
+				node := &IssueNode{Issue: child, Parent: parent, Depth: depth}
+				parent.Children = append(parent.Children, node)
+				nodes[child.ID] = node
+				next = append(next, node)
+			}
+		}
+		level = next
+	}
+	return rootNode, nil
+}
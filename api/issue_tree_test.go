@@ -0,0 +1,126 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/GeekWorkCode/plane-api-go/client"
+	"github.com/GeekWorkCode/plane-api-go/models"
+	"github.com/GeekWorkCode/plane-api-go/planetest"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIssueTree tests sub-issues and fetching an issue tree
+// 测试子问题和获取问题树
+func TestIssueTree(t *testing.T) {
+	srv := planetest.NewServer()
+	defer srv.Close()
+	fx := srv.Seed()
+
+	c := client.NewClient(srv.APIKey)
+	c.SetBaseURL(srv.BaseURL())
+	s := NewIssuesService(c)
+
+	// 创建层级: epic -> (story, chore), story -> task -> subtask
+	create := func(name string, parent string) string {
+		issue, err := s.Create(fx.WorkspaceSlug, fx.ProjectID, &IssueCreateRequest{Name: name, Parent: parent})
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		return issue.ID
+	}
+	epic := create("Epic", "")
+	story := create("Story", epic)
+	create("Chore", epic)
+	task := create("Task", story)
+	subtask := create("Subtask", task)
+
+	t.Run("ListSubIssues", func(t *testing.T) {
+		issues, err := s.ListSubIssues(fx.WorkspaceSlug, fx.ProjectID, epic)
+		if assert.NoError(t, err) && assert.Len(t, issues, 2) {
+			assert.Equal(t, "Story", issues[0].Name)
+			assert.Equal(t, "Chore", issues[1].Name)
+		}
+	})
+
+	t.Run("IgnoredParentFilter", func(t *testing.T) {
+		// 服务器忽略 parent 过滤条件时, 结果在客户端过滤
+		unfiltered := client.NewClient(srv.APIKey)
+		unfiltered.SetBaseURL(srv.BaseURL())
+		unfiltered.Use(client.BeforeRequest(func(req *http.Request) error {
+			query := req.URL.Query()
+			query.Del("parent")
+			req.URL.RawQuery = query.Encode()
+			return nil
+		}))
+		s := NewIssuesService(unfiltered)
+
+		issues, err := s.ListSubIssues(fx.WorkspaceSlug, fx.ProjectID, epic)
+		if assert.NoError(t, err) && assert.Len(t, issues, 2) {
+			assert.Equal(t, "Story", issues[0].Name)
+			assert.Equal(t, "Chore", issues[1].Name)
+		}
+		issues, err = s.ListSubIssues(fx.WorkspaceSlug, fx.ProjectID, subtask)
+		assert.NoError(t, err)
+		assert.Empty(t, issues)
+
+		tree, err := s.GetTree(fx.WorkspaceSlug, fx.ProjectID, epic, nil)
+		if assert.NoError(t, err) {
+			assert.Equal(t, 5, tree.Len())
+			if node := tree.Find(subtask); assert.NotNil(t, node) {
+				assert.Equal(t, 3, node.Depth)
+			}
+		}
+	})
+
+	t.Run("GetTree", func(t *testing.T) {
+		tree, err := s.GetTree(fx.WorkspaceSlug, fx.ProjectID, epic, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, 5, tree.Len())
+		assert.Len(t, tree.Children, 2)
+
+		node := tree.Find(subtask)
+		if assert.NotNil(t, node) {
+			assert.Equal(t, 3, node.Depth)
+			assert.True(t, node.IsLeaf())
+			assert.Equal(t, task, node.Parent.Issue.ID)
+			assert.Equal(t, story, node.Parent.Parent.Issue.ID)
+		}
+		assert.Nil(t, tree.Find(fx.IssueID))
+
+		// Walk 在返回 false 时跳过子节点
+		var names []string
+		tree.Walk(func(n *IssueNode) bool {
+			names = append(names, n.Issue.Name)
+			return n.Issue.Name != "Story"
+		})
+		assert.Equal(t, []string{"Epic", "Story", "Chore"}, names)
+	})
+
+	t.Run("MaxDepth", func(t *testing.T) {
+		tree, err := s.GetTree(fx.WorkspaceSlug, fx.ProjectID, epic, &IssueTreeOptions{MaxDepth: 1})
+		if assert.NoError(t, err) {
+			assert.Equal(t, 3, tree.Len())
+			assert.True(t, tree.Find(story).IsLeaf())
+		}
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		_, err := s.Update(fx.WorkspaceSlug, fx.ProjectID, epic, &IssueUpdateRequest{Parent: models.Value(subtask)})
+		if !assert.NoError(t, err) {
+			return
+		}
+		_, err = s.GetTree(fx.WorkspaceSlug, fx.ProjectID, epic, nil)
+		assert.ErrorIs(t, err, ErrIssueCycle)
+
+		_, err = s.Update(fx.WorkspaceSlug, fx.ProjectID, epic, &IssueUpdateRequest{Parent: models.Null[string]()})
+		assert.NoError(t, err)
+	})
+
+	t.Run("InvalidParent", func(t *testing.T) {
+		_, err := s.Create(fx.WorkspaceSlug, fx.ProjectID, &IssueCreateRequest{Name: "Orphan", Parent: "missing"})
+		assert.True(t, client.IsBadRequest(err))
+	})
+}
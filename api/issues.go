@@ -1,70 +1,313 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/GeekWorkCode/plane-api-go/client"
+	"github.com/GeekWorkCode/plane-api-go/markdown"
 	"github.com/GeekWorkCode/plane-api-go/models"
 )
 
 // IssuesService handles communication with the issue related endpoints
 type IssuesService struct {
-	client *client.Client
+	client   *client.Client
+	resolver *Resolver
 }
 
 // NewIssuesService creates a new issues service
 func NewIssuesService(client *client.Client) *IssuesService {
 	return &IssuesService{
-		client: client,
+		client:   client,
+		resolver: NewResolver(client, DefaultResolverTTL),
 	}
 }
 
-// IssueCreateRequest represents the request body for creating an issue
+// SetResolver makes the service share a name resolver with other services
+func (s *IssuesService) SetResolver(resolver *Resolver) {
+	s.resolver = resolver
+}
+
+// IssueCreateRequest represents the request body for creating an issue.
+// Dates are formatted as YYYY-MM-DD.
 type IssueCreateRequest struct {
-	Name          string   `json:"name"`
-	Description   string   `json:"description,omitempty"`
-	State         string   `json:"state,omitempty"` // 状态ID
-	StateName     string   `json:"-"`               // 状态名称 (不发送到API)
-	Priority      string   `json:"priority,omitempty"`
-	AssigneeID    string   `json:"-"`                   // 分配人ID (不直接发送到API)
-	Assignees     []string `json:"assignees,omitempty"` // 多个分配人ID
-	AssigneeNames []string `json:"-"`                   // 多个分配人名称 (不发送到API)
+	Name                string   `json:"name"`
+	Description         string   `json:"description,omitempty"`
+	DescriptionHTML     string   `json:"description_html,omitempty"`
+	DescriptionMarkdown string   `json:"-"`                              // Markdown 描述, 转换为 description_html (不发送到API)
+	DescriptionStripped string   `json:"description_stripped,omitempty"` // description_html 的纯文本, 发送时自动设置
+	ExpandMentions      bool     `json:"-"`                              // 将描述中的 @显示名称 和 @邮箱 替换为成员提及 (不发送到API)
+	State               string   `json:"state,omitempty"`                // 状态ID
+	StateName           string   `json:"-"`                              // 状态名称 (不发送到API)
+	Priority            string   `json:"priority,omitempty"`
+	AssigneeID          string   `json:"-"`                   // 分配人ID (不直接发送到API)
+	Assignees           []string `json:"assignees,omitempty"` // 多个分配人ID
+	AssigneeNames       []string `json:"-"`                   // 多个分配人名称 (不发送到API)
+	Labels              []string `json:"labels,omitempty"`    // 标签ID
+	LabelNames          []string `json:"-"`                   // 标签名称或路径如 "area/backend" (不发送到API)
+	Parent              string   `json:"parent,omitempty"`    // 父问题ID
+	StartDate           string   `json:"start_date,omitempty"`
+	TargetDate          string   `json:"target_date,omitempty"`
+	EstimatePoint       string   `json:"estimate_point,omitempty"`
+	Point               *int     `json:"point,omitempty"`
+	SortOrder           *float64 `json:"sort_order,omitempty"`
+	IsDraft             bool     `json:"is_draft,omitempty"`
+	TypeID              string   `json:"type_id,omitempty"`
+	ExternalSource      string   `json:"external_source,omitempty"`
+	ExternalID          string   `json:"external_id,omitempty"`
 }
 
-// IssueUpdateRequest represents the request body for updating an issue
+// IssueUpdateRequest represents the request body for updating an issue.
+// Only set fields are sent. Nullable fields can be cleared with models.Null,
+// e.g. TargetDate: models.Null[string]() removes the due date, and
+// Labels: &[]string{} removes all labels and ClearAssignees: true removes all assignees.
 type IssueUpdateRequest struct {
-	Name          string   `json:"name,omitempty"`
-	Description   string   `json:"description,omitempty"`
-	State         string   `json:"state,omitempty"` // 状态ID
-	StateName     string   `json:"-"`               // 状态名称 (不发送到API)
-	Priority      string   `json:"priority,omitempty"`
-	AssigneeID    string   `json:"-"`                   // 分配人ID (不直接发送到API)
-	Assignees     []string `json:"assignees,omitempty"` // 多个分配人ID
-	AssigneeNames []string `json:"-"`                   // 多个分配人名称 (不发送到API)
-}
-
-// List returns all issues in a project
-func (s *IssuesService) List(workspaceSlug string, projectID string) ([]models.Issue, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/issues/", workspaceSlug, projectID)
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	Name                string                   `json:"name,omitempty"`
+	Description         string                   `json:"description,omitempty"`
+	DescriptionHTML     string                   `json:"description_html,omitempty"`
+	DescriptionMarkdown string                   `json:"-"`                              // Markdown 描述, 转换为 description_html (不发送到API)
+	DescriptionStripped string                   `json:"description_stripped,omitempty"` // description_html 的纯文本, 发送时自动设置
+	ExpandMentions      bool                     `json:"-"`                              // 将描述中的 @显示名称 和 @邮箱 替换为成员提及 (不发送到API)
+	State               string                   `json:"state,omitempty"`                // 状态ID
+	StateName           string                   `json:"-"`                              // 状态名称 (不发送到API)
+	Priority            string                   `json:"priority,omitempty"`
+	AssigneeID          string                   `json:"-"`                   // 分配人ID (不直接发送到API)
+	Assignees           []string                 `json:"assignees,omitempty"` // 多个分配人ID
+	ClearAssignees      bool                     `json:"-"`                   // 为 true 时即使 Assignees 为空也发送, 用于移除所有分配人
+	AssigneeNames       []string                 `json:"-"`                   // 多个分配人名称 (不发送到API)
+	Labels              *[]string                `json:"labels,omitempty"`    // 标签ID
+	LabelNames          []string                 `json:"-"`                   // 标签名称或路径如 "area/backend" (不发送到API)
+	Parent              *models.Nullable[string] `json:"parent,omitempty"`    // 父问题ID
+	StartDate           *models.Nullable[string] `json:"start_date,omitempty"`
+	TargetDate          *models.Nullable[string] `json:"target_date,omitempty"`
+	EstimatePoint       *models.Nullable[string] `json:"estimate_point,omitempty"`
+	Point               *models.Nullable[int]    `json:"point,omitempty"`
+	SortOrder           *float64                 `json:"sort_order,omitempty"`
+	IsDraft             *bool                    `json:"is_draft,omitempty"`
+	TypeID              *models.Nullable[string] `json:"type_id,omitempty"`
+	ExternalSource      string                   `json:"external_source,omitempty"`
+	ExternalID          string                   `json:"external_id,omitempty"`
+}
+
+// MarshalJSON encodes the request, sending an empty assignee list when
+// ClearAssignees is set
+func (r IssueUpdateRequest) MarshalJSON() ([]byte, error) {
+	type request IssueUpdateRequest
+	aux := struct {
+		request
+		Assignees *[]string `json:"assignees,omitempty"`
+	}{request: request(r)}
+	if len(r.Assignees) > 0 || r.ClearAssignees {
+		assignees := r.Assignees
+		if assignees == nil {
+			assignees = []string{}
+		}
+		aux.Assignees = &assignees
+	}
+	return json.Marshal(aux)
+}
+
+// Fields that can be expanded into nested objects with IssueListOptions.Expand
+const (
+	ExpandState     = "state"
+	ExpandAssignees = "assignees"
+	ExpandLabels    = "labels"
+)
+
+// DateRange filters a date field of issues. Dates are formatted as YYYY-MM-DD
+// and both bounds are inclusive; an empty bound is open.
+type DateRange struct {
+	After  string
+	Before string
+}
+
+// value encodes the range in Plane's filter format, e.g. "2024-01-01;after,2024-01-31;before"
+func (r *DateRange) value() string {
+	var parts []string
+	if r.After != "" {
+		parts = append(parts, r.After+";after")
+	}
+	if r.Before != "" {
+		parts = append(parts, r.Before+";before")
+	}
+	return strings.Join(parts, ",")
+}
+
+// IssueListOptions filters, orders and expands the issues returned by List,
+// ListPage and Iterate.
+// Filters with several values match issues having any of them.
+// 问题列表的过滤、排序和展开参数
+type IssueListOptions struct {
+	ListOptions
+
+	States      []string // 状态ID
+	StateGroups []string // 状态组, 如 models.StateGroupStarted
+	Priorities  []string // urgent, high, medium, low, none
+	Assignees   []string // 分配人ID
+	Labels      []string // 标签ID
+	CreatedBy   []string // 创建人ID
+	Parents     []string // 父问题ID
+
+	StartDate   *DateRange
+	TargetDate  *DateRange
+	CreatedAt   *DateRange
+	UpdatedAt   *DateRange
+	CompletedAt *DateRange
+
+	// OrderBy is the field to sort by, prefixed with "-" for descending order,
+	// e.g. "-created_at" or "priority".
+	OrderBy string
+	// Expand lists fields returned as nested objects, see ExpandState,
+	// ExpandAssignees and ExpandLabels.
+	Expand []string
+	// Fields restricts the response to the given fields. Empty returns all fields.
+	Fields []string
+	// Archived selects whether archived issues are listed, ExcludeArchived by default.
+	// The API lists archived issues separately, so IncludeArchived needs two listings.
+	Archived ArchivedMode
+}
+
+// values encodes the options as query parameters
+func (o *IssueListOptions) values() url.Values {
+	if o == nil {
+		return url.Values{}
+	}
+	v := o.ListOptions.values()
+	lists := map[string][]string{
+		"state":       o.States,
+		"state_group": o.StateGroups,
+		"priority":    o.Priorities,
+		"assignees":   o.Assignees,
+		"labels":      o.Labels,
+		"created_by":  o.CreatedBy,
+		"parent":      o.Parents,
+		"expand":      o.Expand,
+		"fields":      o.Fields,
+	}
+	for key, list := range lists {
+		if len(list) > 0 {
+			v.Set(key, strings.Join(list, ","))
+		}
+	}
+	dates := map[string]*DateRange{
+		"start_date":   o.StartDate,
+		"target_date":  o.TargetDate,
+		"created_at":   o.CreatedAt,
+		"updated_at":   o.UpdatedAt,
+		"completed_at": o.CompletedAt,
+	}
+	for key, r := range dates {
+		if r != nil && r.value() != "" {
+			v.Set(key, r.value())
+		}
+	}
+	if o.OrderBy != "" {
+		v.Set("order_by", o.OrderBy)
+	}
+	return v
+}
+
+// List returns all issues in a project, optionally filtered by opts
+func (s *IssuesService) List(workspaceSlug string, projectID string, opts ...*IssueListOptions) ([]models.Issue, error) {
+	return s.ListWithContext(context.Background(), workspaceSlug, projectID, opts...)
+}
+
+// ListWithContext returns all issues in a project, following pagination until every page is fetched.
+// Archived and draft issues are left out unless opts selects archived issues.
+// Only the first of opts is used. A cursor cannot be combined with IncludeArchived,
+// as it belongs to the live or the archived listing only.
+func (s *IssuesService) ListWithContext(ctx context.Context, workspaceSlug string, projectID string, opts ...*IssueListOptions) ([]models.Issue, error) {
+	var o *IssueListOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	archived := ExcludeArchived
+	if o != nil {
+		archived = o.Archived
+	}
+	if archived == IncludeArchived && o.Cursor != "" {
+		return nil, errors.New("IncludeArchived 不支持游标, 请分别列出 ExcludeArchived 和 OnlyArchived 的问题")
+	}
+
+	results := []models.Issue{}
+	if archived != OnlyArchived {
+		path := client.Pathf("/workspaces/%s/projects/%s/issues/", workspaceSlug, projectID)
+		live, err := listAll[models.Issue](ctx, s.client, path, o.values())
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, live...)
+	}
+	if archived != ExcludeArchived {
+		path := client.Pathf("/workspaces/%s/projects/%s/archived-issues/", workspaceSlug, projectID)
+		archivedIssues, err := listAll[models.Issue](ctx, s.client, path, o.values())
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, archivedIssues...)
+	}
+	return results, nil
+}
+
+// ListPage returns a single page of issues in a project, optionally filtered by opts
+func (s *IssuesService) ListPage(workspaceSlug string, projectID string, opts *IssueListOptions) (*models.PaginatedResponse[models.Issue], error) {
+	return s.ListPageWithContext(context.Background(), workspaceSlug, projectID, opts)
+}
+
+// ListPageWithContext returns a single page of issues in a project, filtered,
+// ordered and expanded like List. opts.Archived may not be IncludeArchived.
+func (s *IssuesService) ListPageWithContext(ctx context.Context, workspaceSlug string, projectID string, opts *IssueListOptions) (*models.PaginatedResponse[models.Issue], error) {
+	path, err := issuePagePath(workspaceSlug, projectID, opts)
 	if err != nil {
 		return nil, err
 	}
+	page, err := listPage[models.Issue](ctx, s.client, path, opts.values())
+	return page, err
+}
 
-	response := new(models.IssuesResponse)
-	_, err = s.client.Do(req, response)
+// Iterate returns an iterator over all issues in a project selected by opts, starting at opts.Cursor.
+// opts.Archived may not be IncludeArchived.
+func (s *IssuesService) Iterate(ctx context.Context, workspaceSlug string, projectID string, opts *IssueListOptions) *Iterator[models.Issue] {
+	path, err := issuePagePath(workspaceSlug, projectID, opts)
 	if err != nil {
-		return nil, err
+		it := newIterator[models.Issue](ctx, nil)
+		it.err = err
+		return it
 	}
+	return newListIterator[models.Issue](ctx, s.client, path, opts.values())
+}
 
-	return response.Results, nil
+// issuePagePath returns the path listing the live or archived issues of a project
+// page by page. Live and archived issues are listed separately, so IncludeArchived
+// cannot be paged.
+func issuePagePath(workspaceSlug string, projectID string, opts *IssueListOptions) (string, error) {
+	archived := ExcludeArchived
+	if opts != nil {
+		archived = opts.Archived
+	}
+	switch archived {
+	case ExcludeArchived:
+		return client.Pathf("/workspaces/%s/projects/%s/issues/", workspaceSlug, projectID), nil
+	case OnlyArchived:
+		return client.Pathf("/workspaces/%s/projects/%s/archived-issues/", workspaceSlug, projectID), nil
+	}
+	return "", errors.New("分页列表不支持 IncludeArchived, 请分别列出 ExcludeArchived 和 OnlyArchived 的问题")
 }
 
 // Get returns an issue by its ID
 func (s *IssuesService) Get(workspaceSlug string, projectID string, issueID string) (*models.Issue, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/issues/%s", workspaceSlug, projectID, issueID)
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	return s.GetWithContext(context.Background(), workspaceSlug, projectID, issueID)
+}
+
+// GetWithContext returns an issue by its ID
+func (s *IssuesService) GetWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string) (*models.Issue, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/", workspaceSlug, projectID, issueID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -76,8 +319,13 @@ func (s *IssuesService) Get(workspaceSlug string, projectID string, issueID stri
 
 // GetBySequenceID returns an issue by its sequence ID
 func (s *IssuesService) GetBySequenceID(workspaceSlug string, sequenceID string) (*models.Issue, error) {
-	path := fmt.Sprintf("/workspaces/%s/issues/%s/", workspaceSlug, sequenceID)
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	return s.GetBySequenceIDWithContext(context.Background(), workspaceSlug, sequenceID)
+}
+
+// GetBySequenceIDWithContext returns an issue by its sequence ID
+func (s *IssuesService) GetBySequenceIDWithContext(ctx context.Context, workspaceSlug string, sequenceID string) (*models.Issue, error) {
+	path := client.Pathf("/workspaces/%s/issues/%s/", workspaceSlug, sequenceID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -88,46 +336,51 @@ func (s *IssuesService) GetBySequenceID(workspaceSlug string, sequenceID string)
 }
 
 // findStateIDByName 通过状态名称查找状态ID
-func (s *IssuesService) findStateIDByName(workspaceSlug string, projectID string, stateName string) (string, error) {
-	// 获取项目所有状态
-	statesService := NewStatesService(s.client)
-	states, err := statesService.List(workspaceSlug, projectID)
-	if err != nil {
-		return "", fmt.Errorf("获取状态列表失败: %w", err)
-	}
+func (s *IssuesService) findStateIDByName(ctx context.Context, workspaceSlug string, projectID string, stateName string) (string, error) {
+	return s.resolver.StateID(ctx, workspaceSlug, projectID, stateName)
+}
 
-	for _, state := range states {
-		if state.Name == stateName {
-			return state.ID, nil
-		}
-	}
-	return "", fmt.Errorf("未找到名称为 '%s' 的状态", stateName)
+// findMemberIDByName 通过成员名称查找成员ID, 支持显示名称、姓名和邮箱
+func (s *IssuesService) findMemberIDByName(ctx context.Context, workspaceSlug string, projectID string, memberName string) (string, error) {
+	return s.resolver.MemberID(ctx, workspaceSlug, projectID, memberName)
 }
 
-// findMemberIDByName 通过成员名称查找成员ID
-func (s *IssuesService) findMemberIDByName(workspaceSlug string, projectID string, memberName string) (string, error) {
-	// 获取项目所有成员
-	membersService := NewMembersService(s.client)
-	members, err := membersService.List(workspaceSlug, projectID)
-	if err != nil {
-		return "", fmt.Errorf("获取成员列表失败: %w", err)
+// setDescription converts a Markdown description to HTML, expands @mentions if
+// mentions is set and sets the plain text of the HTML description, which Plane uses
+// for search. The Markdown and the mentions flag are cleared once the HTML is derived
+// from them, so a request that is resolved again keeps its HTML.
+func (s *IssuesService) setDescription(ctx context.Context, workspaceSlug string, projectID string, descriptionHTML *string, descriptionStripped *string, descriptionMarkdown *string, mentions *bool) error {
+	if *descriptionMarkdown != "" {
+		*descriptionHTML = markdown.ToHTML(*descriptionMarkdown)
+		*descriptionMarkdown = ""
 	}
-
-	for _, member := range members {
-		if member.Member.DisplayName == memberName {
-			return member.Member.ID, nil
+	if *mentions && *descriptionHTML != "" {
+		html, err := expandMentions(ctx, s.resolver, workspaceSlug, projectID, *descriptionHTML)
+		if err != nil {
+			return err
 		}
+		*descriptionHTML = html
+		*mentions = false
+	}
+	if *descriptionHTML != "" {
+		*descriptionStripped = markdown.Strip(*descriptionHTML)
 	}
-	return "", fmt.Errorf("未找到名称为 '%s' 的成员", memberName)
+	return nil
 }
 
-// Create creates a new issue
-func (s *IssuesService) Create(workspaceSlug string, projectID string, createRequest *IssueCreateRequest) (*models.Issue, error) {
+// resolveCreateNames sets the IDs of the states, assignees and labels given by name
+// in a create request, and the HTML of a Markdown description
+func (s *IssuesService) resolveCreateNames(ctx context.Context, workspaceSlug string, projectID string, createRequest *IssueCreateRequest) error {
+	err := s.setDescription(ctx, workspaceSlug, projectID, &createRequest.DescriptionHTML, &createRequest.DescriptionStripped, &createRequest.DescriptionMarkdown, &createRequest.ExpandMentions)
+	if err != nil {
+		return err
+	}
+
 	// 如果提供了状态名称，查找对应的状态ID
 	if createRequest.StateName != "" {
-		stateID, err := s.findStateIDByName(workspaceSlug, projectID, createRequest.StateName)
+		stateID, err := s.findStateIDByName(ctx, workspaceSlug, projectID, createRequest.StateName)
 		if err != nil {
-			return nil, fmt.Errorf("查找状态失败: %w", err)
+			return fmt.Errorf("查找状态失败: %w", err)
 		}
 		createRequest.State = stateID
 	}
@@ -136,33 +389,39 @@ func (s *IssuesService) Create(workspaceSlug string, projectID string, createReq
 	if len(createRequest.AssigneeNames) > 0 {
 		assigneeIDs := make([]string, 0, len(createRequest.AssigneeNames))
 		for _, name := range createRequest.AssigneeNames {
-			memberID, err := s.findMemberIDByName(workspaceSlug, projectID, name)
+			memberID, err := s.findMemberIDByName(ctx, workspaceSlug, projectID, name)
 			if err != nil {
-				return nil, fmt.Errorf("查找成员失败: %w", err)
+				return fmt.Errorf("查找成员失败: %w", err)
 			}
 			assigneeIDs = append(assigneeIDs, memberID)
 		}
 		createRequest.Assignees = assigneeIDs
 	}
 
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/issues/", workspaceSlug, projectID)
-	req, err := s.client.NewRequest(http.MethodPost, path, createRequest)
-	if err != nil {
-		return nil, err
+	// 如果提供了标签名称，查找对应的标签ID
+	if len(createRequest.LabelNames) > 0 {
+		labelIDs, err := s.findLabelIDsByName(ctx, workspaceSlug, projectID, createRequest.LabelNames)
+		if err != nil {
+			return fmt.Errorf("查找标签失败: %w", err)
+		}
+		createRequest.Labels = labelIDs
 	}
-
-	issue := new(models.Issue)
-	_, err = s.client.Do(req, issue)
-	return issue, err
+	return nil
 }
 
-// Update updates an issue
-func (s *IssuesService) Update(workspaceSlug string, projectID string, issueID string, updateRequest *IssueUpdateRequest) (*models.Issue, error) {
+// resolveUpdateNames sets the IDs of the states, assignees and labels given by name
+// in an update request, and the HTML of a Markdown description
+func (s *IssuesService) resolveUpdateNames(ctx context.Context, workspaceSlug string, projectID string, updateRequest *IssueUpdateRequest) error {
+	err := s.setDescription(ctx, workspaceSlug, projectID, &updateRequest.DescriptionHTML, &updateRequest.DescriptionStripped, &updateRequest.DescriptionMarkdown, &updateRequest.ExpandMentions)
+	if err != nil {
+		return err
+	}
+
 	// 如果提供了状态名称,查找对应的状态ID
 	if updateRequest.StateName != "" && updateRequest.State == "" {
-		stateID, err := s.findStateIDByName(workspaceSlug, projectID, updateRequest.StateName)
+		stateID, err := s.findStateIDByName(ctx, workspaceSlug, projectID, updateRequest.StateName)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		updateRequest.State = stateID
 	}
@@ -171,17 +430,65 @@ func (s *IssuesService) Update(workspaceSlug string, projectID string, issueID s
 	if len(updateRequest.AssigneeNames) > 0 && len(updateRequest.Assignees) == 0 {
 		memberIDs := make([]string, 0, len(updateRequest.AssigneeNames))
 		for _, memberName := range updateRequest.AssigneeNames {
-			memberID, err := s.findMemberIDByName(workspaceSlug, projectID, memberName)
+			memberID, err := s.findMemberIDByName(ctx, workspaceSlug, projectID, memberName)
 			if err != nil {
-				return nil, err
+				return err
 			}
 			memberIDs = append(memberIDs, memberID)
 		}
 		updateRequest.Assignees = memberIDs
 	}
 
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/issues/%s/", workspaceSlug, projectID, issueID)
-	req, err := s.client.NewRequest(http.MethodPatch, path, updateRequest)
+	// 如果提供了标签名称,查找对应的标签ID
+	if len(updateRequest.LabelNames) > 0 && updateRequest.Labels == nil {
+		labelIDs, err := s.findLabelIDsByName(ctx, workspaceSlug, projectID, updateRequest.LabelNames)
+		if err != nil {
+			return err
+		}
+		updateRequest.Labels = &labelIDs
+	}
+	return nil
+}
+
+// Create creates a new issue
+func (s *IssuesService) Create(workspaceSlug string, projectID string, createRequest *IssueCreateRequest) (*models.Issue, error) {
+	return s.CreateWithContext(context.Background(), workspaceSlug, projectID, createRequest)
+}
+
+// CreateWithContext creates a new issue
+func (s *IssuesService) CreateWithContext(ctx context.Context, workspaceSlug string, projectID string, createRequest *IssueCreateRequest) (*models.Issue, error) {
+	if err := s.resolveCreateNames(ctx, workspaceSlug, projectID, createRequest); err != nil {
+		return nil, err
+	}
+
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/", workspaceSlug, projectID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, path, createRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	issue := new(models.Issue)
+	_, err = s.client.Do(req, issue)
+	return issue, err
+}
+
+// Update updates an issue
+func (s *IssuesService) Update(workspaceSlug string, projectID string, issueID string, updateRequest *IssueUpdateRequest) (*models.Issue, error) {
+	return s.UpdateWithContext(context.Background(), workspaceSlug, projectID, issueID, updateRequest)
+}
+
+// UpdateWithContext updates an issue
+func (s *IssuesService) UpdateWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, updateRequest *IssueUpdateRequest) (*models.Issue, error) {
+	if err := s.resolveUpdateNames(ctx, workspaceSlug, projectID, updateRequest); err != nil {
+		return nil, err
+	}
+	return s.update(ctx, workspaceSlug, projectID, issueID, updateRequest)
+}
+
+// update sends an update request whose names are already resolved
+func (s *IssuesService) update(ctx context.Context, workspaceSlug string, projectID string, issueID string, updateRequest *IssueUpdateRequest) (*models.Issue, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/", workspaceSlug, projectID, issueID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPatch, path, updateRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -193,8 +500,13 @@ func (s *IssuesService) Update(workspaceSlug string, projectID string, issueID s
 
 // Delete deletes an issue
 func (s *IssuesService) Delete(workspaceSlug string, projectID string, issueID string) error {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/issues/%s/", workspaceSlug, projectID, issueID)
-	req, err := s.client.NewRequest(http.MethodDelete, path, nil)
+	return s.DeleteWithContext(context.Background(), workspaceSlug, projectID, issueID)
+}
+
+// DeleteWithContext deletes an issue
+func (s *IssuesService) DeleteWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string) error {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/", workspaceSlug, projectID, issueID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodDelete, path, nil)
 	if err != nil {
 		return err
 	}
@@ -205,37 +517,24 @@ func (s *IssuesService) Delete(workspaceSlug string, projectID string, issueID s
 
 // UpdateBySequenceID updates an issue by its sequence ID
 func (s *IssuesService) UpdateBySequenceID(workspaceSlug string, sequenceID string, updateRequest *IssueUpdateRequest) (*models.Issue, error) {
+	return s.UpdateBySequenceIDWithContext(context.Background(), workspaceSlug, sequenceID, updateRequest)
+}
+
+// UpdateBySequenceIDWithContext updates an issue by its sequence ID
+func (s *IssuesService) UpdateBySequenceIDWithContext(ctx context.Context, workspaceSlug string, sequenceID string, updateRequest *IssueUpdateRequest) (*models.Issue, error) {
 	// 获取项目ID，通过序列ID获取项目ID
-	issue, err := s.GetBySequenceID(workspaceSlug, sequenceID)
+	issue, err := s.GetBySequenceIDWithContext(ctx, workspaceSlug, sequenceID)
 	if err != nil {
 		return nil, fmt.Errorf("通过序列ID获取问题失败: %w", err)
 	}
 	projectID := issue.Project
 
-	// 如果提供了状态名称,查找对应的状态ID
-	if updateRequest.StateName != "" && updateRequest.State == "" {
-		stateID, err := s.findStateIDByName(workspaceSlug, projectID, updateRequest.StateName)
-		if err != nil {
-			return nil, err
-		}
-		updateRequest.State = stateID
-	}
-
-	// 如果提供了成员名称,查找对应的成员ID
-	if len(updateRequest.AssigneeNames) > 0 && len(updateRequest.Assignees) == 0 {
-		memberIDs := make([]string, 0, len(updateRequest.AssigneeNames))
-		for _, memberName := range updateRequest.AssigneeNames {
-			memberID, err := s.findMemberIDByName(workspaceSlug, projectID, memberName)
-			if err != nil {
-				return nil, err
-			}
-			memberIDs = append(memberIDs, memberID)
-		}
-		updateRequest.Assignees = memberIDs
+	if err := s.resolveUpdateNames(ctx, workspaceSlug, projectID, updateRequest); err != nil {
+		return nil, err
 	}
 
-	path := fmt.Sprintf("/workspaces/%s/issues/%s/", workspaceSlug, sequenceID)
-	req, err := s.client.NewRequest(http.MethodPatch, path, updateRequest)
+	path := client.Pathf("/workspaces/%s/issues/%s/", workspaceSlug, sequenceID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPatch, path, updateRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,187 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/GeekWorkCode/plane-api-go/models"
+)
+
+// DefaultBulkConcurrency is the number of requests a bulk operation sends at once by default
+const DefaultBulkConcurrency = 4
+
+// BulkOptions configures a bulk operation
+type BulkOptions struct {
+	// Concurrency is the number of requests sent at once, DefaultBulkConcurrency if zero.
+	// Requests still go through the rate limiter of the client.
+	Concurrency int
+
+	// DryRun resolves names and checks that the issues exist without changing anything
+	DryRun bool
+
+	// StopOnError skips the remaining items after the first failure.
+	// Skipped items fail with context.Canceled.
+	StopOnError bool
+}
+
+func (o *BulkOptions) concurrency(items int) int {
+	n := DefaultBulkConcurrency
+	if o != nil && o.Concurrency > 0 {
+		n = o.Concurrency
+	}
+	if n > items {
+		n = items
+	}
+	return n
+}
+
+// BulkResult is the outcome of one item of a bulk operation
+type BulkResult struct {
+	Index   int           // position of the item in the input
+	IssueID string        // ID of the issue, empty if a create failed or was a dry run
+	Issue   *models.Issue // the created or updated issue, in a dry run the current issue
+	Err     error
+}
+
+// BulkError is returned by bulk operations when some of the items failed
+// 批量操作中部分条目失败
+type BulkError struct {
+	Total  int
+	Failed []BulkResult
+}
+
+// Error implements the error interface
+func (e *BulkError) Error() string {
+	return fmt.Sprintf("批量操作中 %d/%d 项失败, 第 %d 项: %v", len(e.Failed), e.Total, e.Failed[0].Index, e.Failed[0].Err)
+}
+
+// Unwrap returns the errors of the failed items
+func (e *BulkError) Unwrap() []error {
+	errs := make([]error, len(e.Failed))
+	for i, result := range e.Failed {
+		errs[i] = result.Err
+	}
+	return errs
+}
+
+// runBulk calls do for every item from a bounded pool of workers and returns the
+// results in input order, with a *BulkError if any item failed
+func runBulk(ctx context.Context, items int, opts *BulkOptions, do func(ctx context.Context, i int) BulkResult) ([]BulkResult, error) {
+	results := make([]BulkResult, items)
+	if items == 0 {
+		return results, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stopOnError := opts != nil && opts.StopOnError
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < opts.concurrency(items); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := ctx.Err(); err != nil {
+					results[i] = BulkResult{Index: i, Err: err}
+					continue
+				}
+				result := do(ctx, i)
+				result.Index = i
+				results[i] = result
+				if result.Err != nil && stopOnError {
+					cancel()
+				}
+			}
+		}()
+	}
+	for i := 0; i < items; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	var failed []BulkResult
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	if len(failed) > 0 {
+		return results, &BulkError{Total: items, Failed: failed}
+	}
+	return results, nil
+}
+
+// BulkCreate creates issues concurrently
+func (s *IssuesService) BulkCreate(workspaceSlug string, projectID string, createRequests []*IssueCreateRequest, opts *BulkOptions) ([]BulkResult, error) {
+	return s.BulkCreateWithContext(context.Background(), workspaceSlug, projectID, createRequests, opts)
+}
+
+// BulkCreateWithContext creates issues concurrently. Results are in the order of the
+// requests, and the error is a *BulkError if any of them failed. In a dry run only
+// the names in the requests are resolved.
+func (s *IssuesService) BulkCreateWithContext(ctx context.Context, workspaceSlug string, projectID string, createRequests []*IssueCreateRequest, opts *BulkOptions) ([]BulkResult, error) {
+	return runBulk(ctx, len(createRequests), opts, func(ctx context.Context, i int) BulkResult {
+		if opts != nil && opts.DryRun {
+			return BulkResult{Err: s.resolveCreateNames(ctx, workspaceSlug, projectID, createRequests[i])}
+		}
+		issue, err := s.CreateWithContext(ctx, workspaceSlug, projectID, createRequests[i])
+		if err != nil {
+			return BulkResult{Err: err}
+		}
+		return BulkResult{IssueID: issue.ID, Issue: issue}
+	})
+}
+
+// BulkUpdate applies the same update to issues concurrently
+func (s *IssuesService) BulkUpdate(workspaceSlug string, projectID string, issueIDs []string, updateRequest *IssueUpdateRequest, opts *BulkOptions) ([]BulkResult, error) {
+	return s.BulkUpdateWithContext(context.Background(), workspaceSlug, projectID, issueIDs, updateRequest, opts)
+}
+
+// BulkUpdateWithContext applies the same update to issues concurrently, e.g. to move
+// them to another state. Names and the Markdown description in the request are
+// resolved once before any issue is updated, and the workers only read the resolved
+// request. Results are in the order of issueIDs, and the error is a *BulkError if
+// any update failed. A dry run fetches the issues instead of updating them.
+func (s *IssuesService) BulkUpdateWithContext(ctx context.Context, workspaceSlug string, projectID string, issueIDs []string, updateRequest *IssueUpdateRequest, opts *BulkOptions) ([]BulkResult, error) {
+	if err := s.resolveUpdateNames(ctx, workspaceSlug, projectID, updateRequest); err != nil {
+		return nil, err
+	}
+	return runBulk(ctx, len(issueIDs), opts, func(ctx context.Context, i int) BulkResult {
+		var issue *models.Issue
+		var err error
+		if opts != nil && opts.DryRun {
+			issue, err = s.GetWithContext(ctx, workspaceSlug, projectID, issueIDs[i])
+		} else {
+			issue, err = s.update(ctx, workspaceSlug, projectID, issueIDs[i], updateRequest)
+		}
+		if err != nil {
+			return BulkResult{IssueID: issueIDs[i], Err: err}
+		}
+		return BulkResult{IssueID: issueIDs[i], Issue: issue}
+	})
+}
+
+// BulkDelete deletes issues concurrently
+func (s *IssuesService) BulkDelete(workspaceSlug string, projectID string, issueIDs []string, opts *BulkOptions) ([]BulkResult, error) {
+	return s.BulkDeleteWithContext(context.Background(), workspaceSlug, projectID, issueIDs, opts)
+}
+
+// BulkDeleteWithContext deletes issues concurrently. Results are in the order of
+// issueIDs, and the error is a *BulkError if any delete failed. A dry run fetches
+// the issues instead of deleting them.
+func (s *IssuesService) BulkDeleteWithContext(ctx context.Context, workspaceSlug string, projectID string, issueIDs []string, opts *BulkOptions) ([]BulkResult, error) {
+	return runBulk(ctx, len(issueIDs), opts, func(ctx context.Context, i int) BulkResult {
+		if opts != nil && opts.DryRun {
+			issue, err := s.GetWithContext(ctx, workspaceSlug, projectID, issueIDs[i])
+			if err != nil {
+				return BulkResult{IssueID: issueIDs[i], Err: err}
+			}
+			return BulkResult{IssueID: issueIDs[i], Issue: issue}
+		}
+		return BulkResult{IssueID: issueIDs[i], Err: s.DeleteWithContext(ctx, workspaceSlug, projectID, issueIDs[i])}
+	})
+}
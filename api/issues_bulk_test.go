@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/GeekWorkCode/plane-api-go/client"
+	"github.com/GeekWorkCode/plane-api-go/planetest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newBulkTestService returns an issues service for a seeded fake server and a
+// function returning the number of requests sent per method
+func newBulkTestService(t *testing.T) (*IssuesService, planetest.Fixture, func(method string) int) {
+	srv := planetest.NewServer()
+	t.Cleanup(srv.Close)
+	fx := srv.Seed()
+
+	c := client.NewClient(srv.APIKey)
+	c.SetBaseURL(srv.BaseURL())
+	var mu sync.Mutex
+	methods := map[string]int{}
+	c.Use(client.BeforeRequest(func(req *http.Request) error {
+		mu.Lock()
+		defer mu.Unlock()
+		methods[req.Method]++
+		return nil
+	}))
+	return NewIssuesService(c), fx, func(method string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return methods[method]
+	}
+}
+
+// TestIssuesServiceBulk tests bulk create, update and delete of issues
+// 测试批量创建、更新和删除问题
+func TestIssuesServiceBulk(t *testing.T) {
+	issues, fx, requests := newBulkTestService(t)
+	ws, proj := fx.WorkspaceSlug, fx.ProjectID
+
+	createRequests := make([]*IssueCreateRequest, 10)
+	for i := range createRequests {
+		createRequests[i] = &IssueCreateRequest{Name: "Bulk issue", StateName: "Todo"}
+	}
+	created, err := issues.BulkCreate(ws, proj, createRequests, &BulkOptions{Concurrency: 3})
+	if !assert.NoError(t, err) {
+		return
+	}
+	ids := make([]string, len(created))
+	for i, result := range created {
+		assert.Equal(t, i, result.Index)
+		assert.NotEmpty(t, result.IssueID)
+		ids[i] = result.IssueID
+	}
+
+	t.Run("Update", func(t *testing.T) {
+		targets := append([]string{"missing-issue"}, ids...)
+		results, err := issues.BulkUpdate(ws, proj, targets, &IssueUpdateRequest{StateName: "Done"}, nil)
+		var bulkErr *BulkError
+		if !assert.True(t, errors.As(err, &bulkErr)) {
+			return
+		}
+		assert.Equal(t, len(targets), bulkErr.Total)
+		assert.Len(t, bulkErr.Failed, 1)
+		assert.Equal(t, "missing-issue", bulkErr.Failed[0].IssueID)
+		assert.True(t, client.IsNotFound(err))
+
+		done := results[1].Issue.State
+		for _, result := range results[1:] {
+			assert.NoError(t, result.Err)
+			assert.Equal(t, done, result.Issue.State)
+		}
+	})
+
+	t.Run("Description", func(t *testing.T) {
+		// 并发的更新共享同一个请求, 使用 -race 运行时检查数据竞争
+		request := &IssueUpdateRequest{DescriptionMarkdown: "Moved to **Done**"}
+		results, err := issues.BulkUpdate(ws, proj, ids, request, &BulkOptions{Concurrency: 8})
+		if !assert.NoError(t, err) {
+			return
+		}
+		for _, result := range results {
+			assert.Equal(t, "<p>Moved to <strong>Done</strong></p>", result.Issue.DescriptionHTML)
+			assert.Equal(t, "Moved to Done", result.Issue.DescriptionStripped)
+		}
+		assert.Equal(t, "<p>Moved to <strong>Done</strong></p>", request.DescriptionHTML)
+		assert.Empty(t, request.DescriptionMarkdown)
+	})
+
+	t.Run("UnknownState", func(t *testing.T) {
+		patches := requests(http.MethodPatch)
+		results, err := issues.BulkUpdate(ws, proj, ids, &IssueUpdateRequest{StateName: "Nope"}, nil)
+		assert.True(t, client.IsNotFound(err))
+		assert.Nil(t, results)
+		assert.Equal(t, patches, requests(http.MethodPatch))
+	})
+
+	t.Run("DryRun", func(t *testing.T) {
+		writes := requests(http.MethodPost) + requests(http.MethodPatch) + requests(http.MethodDelete)
+		dryRun := &BulkOptions{DryRun: true}
+
+		results, err := issues.BulkUpdate(ws, proj, ids, &IssueUpdateRequest{StateName: "Backlog"}, dryRun)
+		assert.NoError(t, err)
+		assert.Len(t, results, len(ids))
+		_, err = issues.BulkDelete(ws, proj, []string{ids[0], "missing-issue"}, dryRun)
+		assert.True(t, client.IsNotFound(err))
+		_, err = issues.BulkCreate(ws, proj, []*IssueCreateRequest{{Name: "x", AssigneeNames: []string{"nobody"}}}, dryRun)
+		assert.True(t, client.IsNotFound(err))
+
+		assert.Equal(t, writes, requests(http.MethodPost)+requests(http.MethodPatch)+requests(http.MethodDelete))
+	})
+
+	t.Run("StopOnError", func(t *testing.T) {
+		targets := []string{"missing-issue", ids[0], ids[1], ids[2]}
+		results, err := issues.BulkDelete(ws, proj, targets, &BulkOptions{Concurrency: 1, StopOnError: true})
+		assert.Error(t, err)
+		for _, result := range results[1:] {
+			assert.True(t, errors.Is(result.Err, context.Canceled))
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		_, err := issues.BulkDelete(ws, proj, ids, &BulkOptions{Concurrency: 5})
+		assert.NoError(t, err)
+		for _, id := range ids {
+			_, err := issues.Get(ws, proj, id)
+			assert.True(t, client.IsNotFound(err))
+		}
+	})
+}
@@ -1,33 +1,35 @@
 package api
 
 import (
+	"bytes"
+	"context"
 	"fmt"
-	"os"
+	"io"
+	"net/http"
 	"testing"
 
 	"github.com/GeekWorkCode/plane-api-go/client"
+	"github.com/GeekWorkCode/plane-api-go/models"
+	"github.com/GeekWorkCode/plane-api-go/planetest"
 	"github.com/stretchr/testify/assert"
 )
 
 // TestIssuesService tests all methods of the IssuesService
 // 测试 IssuesService 的所有方法
 func TestIssuesService(t *testing.T) {
-	// Get API key from environment
-	apiKey := os.Getenv("PLANE_API_KEY")
-	if apiKey == "" {
-		t.Skip("PLANE_API_KEY not set")
-	}
+	// Use the live API if configured, the fake server otherwise
+	env := newTestEnv(t)
 
-	// Get required IDs from environment
-	workspaceSlug := os.Getenv("PLANE_WORKSPACE_SLUG")
-	projectID := os.Getenv("PLANE_PROJECT_ID")
+	// Get required IDs from the test environment
+	workspaceSlug := env.workspaceSlug
+	projectID := env.projectID
 
 	if workspaceSlug == "" || projectID == "" {
 		t.Skip("Required environment variables not set")
 	}
 
 	// Create a new client
-	client := client.NewClient(apiKey)
+	client := env.client
 	s := NewIssuesService(client)
 
 	var issueID string
@@ -41,15 +43,12 @@ func TestIssuesService(t *testing.T) {
 		assert.NotNil(t, issues)
 
 		// 如果有问题存在，获取第一个问题的序列ID用于后续测试
+		// 序列ID以"PROJECT_IDENTIFIER-NUMBER"格式存在
 		if len(issues) > 0 {
-			// 在实际情况中，sequenceID通常以"PROJECT_IDENTIFIER-NUMBER"格式存在
-			// 这里假设我们已知道项目标识符，或者可以从项目ID获取
-			// 以下只是示例，实际情况需要根据API响应结构调整
 			projectsService := NewProjectsService(client)
 			project, err := projectsService.Get(workspaceSlug, projectID)
 			if err == nil && project != nil {
-				// 假设问题序列号为1，实际中应该从问题数据中获取
-				sequenceID = fmt.Sprintf("%s-%d", project.Identifier, 1)
+				sequenceID = fmt.Sprintf("%s-%d", project.Identifier, issues[0].SequenceID)
 			}
 		}
 	})
@@ -122,6 +121,30 @@ func TestIssuesService(t *testing.T) {
 		assert.Equal(t, updateReq.Description, issue.Description)
 	})
 
+	// Test clearing a nullable field with Update
+	// 测试使用 Update 清空可为空的字段
+	t.Run("UpdateClearTargetDate", func(t *testing.T) {
+		issue, err := s.Update(workspaceSlug, projectID, issueID, &IssueUpdateRequest{
+			StartDate:  models.Value("2024-01-01"),
+			TargetDate: models.Value("2024-01-31"),
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+		if assert.NotNil(t, issue.TargetDate) {
+			assert.Equal(t, "2024-01-31", *issue.TargetDate)
+		}
+
+		issue, err = s.Update(workspaceSlug, projectID, issueID, &IssueUpdateRequest{
+			TargetDate: models.Null[string](),
+		})
+		assert.NoError(t, err)
+		assert.Nil(t, issue.TargetDate)
+		if assert.NotNil(t, issue.StartDate) {
+			assert.Equal(t, "2024-01-01", *issue.StartDate)
+		}
+	})
+
 	// Test Delete method
 	// 测试 Delete 方法
 	t.Run("Delete", func(t *testing.T) {
@@ -129,3 +152,222 @@ func TestIssuesService(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+// TestIssueListOptions tests the encoding of issue filters as query parameters
+// 测试问题过滤参数的编码
+func TestIssueListOptions(t *testing.T) {
+	opts := &IssueListOptions{
+		ListOptions: ListOptions{PerPage: 20},
+		StateGroups: []string{models.StateGroupUnstarted, models.StateGroupStarted},
+		Priorities:  []string{"high", "urgent"},
+		TargetDate:  &DateRange{After: "2024-01-01", Before: "2024-01-31"},
+		CreatedAt:   &DateRange{Before: "2024-02-01"},
+		OrderBy:     "-priority",
+		Expand:      []string{ExpandAssignees, ExpandState},
+	}
+	v := opts.values()
+	assert.Equal(t, "20", v.Get("per_page"))
+	assert.Equal(t, "unstarted,started", v.Get("state_group"))
+	assert.Equal(t, "high,urgent", v.Get("priority"))
+	assert.Equal(t, "2024-01-01;after,2024-01-31;before", v.Get("target_date"))
+	assert.Equal(t, "2024-02-01;before", v.Get("created_at"))
+	assert.Equal(t, "-priority", v.Get("order_by"))
+	assert.Equal(t, "assignees,state", v.Get("expand"))
+	assert.False(t, v.Has("labels"))
+
+	var nilOpts *IssueListOptions
+	assert.Empty(t, nilOpts.values())
+}
+
+// TestIssuesServiceListFiltered tests filtering, ordering and expanding issues
+// 测试问题的过滤、排序和展开
+func TestIssuesServiceListFiltered(t *testing.T) {
+	srv := planetest.NewServer()
+	defer srv.Close()
+	fx := srv.Seed()
+
+	c := client.NewClient(srv.APIKey)
+	c.SetBaseURL(srv.BaseURL())
+	s := NewIssuesService(c)
+
+	states, err := NewStatesService(c).List(fx.WorkspaceSlug, fx.ProjectID)
+	if !assert.NoError(t, err) {
+		return
+	}
+	stateIDs := map[string]string{}
+	for _, state := range states {
+		stateIDs[state.Group] = state.ID
+	}
+	alice := fx.Members[0].ID
+	due := "2024-01-15"
+	srv.AddIssue(fx.WorkspaceSlug, fx.ProjectID, models.Issue{Name: "Open bug", Priority: "high", State: stateIDs[models.StateGroupStarted], Assignees: []string{alice}, TargetDate: &due})
+	srv.AddIssue(fx.WorkspaceSlug, fx.ProjectID, models.Issue{Name: "Urgent bug", Priority: "urgent", State: stateIDs[models.StateGroupUnstarted], Assignees: []string{alice}})
+	srv.AddIssue(fx.WorkspaceSlug, fx.ProjectID, models.Issue{Name: "Closed bug", Priority: "high", State: stateIDs[models.StateGroupCompleted], Assignees: []string{alice}})
+
+	t.Run("Filter", func(t *testing.T) {
+		issues, err := s.List(fx.WorkspaceSlug, fx.ProjectID, &IssueListOptions{
+			StateGroups: []string{models.StateGroupUnstarted, models.StateGroupStarted},
+			Priorities:  []string{"high", "urgent"},
+			Assignees:   []string{alice},
+			OrderBy:     "priority",
+		})
+		if !assert.NoError(t, err) || !assert.Len(t, issues, 2) {
+			return
+		}
+		assert.Equal(t, "Urgent bug", issues[0].Name)
+		assert.Equal(t, "Open bug", issues[1].Name)
+	})
+
+	t.Run("Paged", func(t *testing.T) {
+		opts := &IssueListOptions{
+			ListOptions: ListOptions{PerPage: 1},
+			Priorities:  []string{"high", "urgent"},
+			Assignees:   []string{alice},
+			OrderBy:     "priority",
+			Expand:      []string{ExpandState},
+		}
+		page, err := s.ListPage(fx.WorkspaceSlug, fx.ProjectID, opts)
+		if assert.NoError(t, err) && assert.Len(t, page.Results, 1) {
+			assert.Equal(t, "Urgent bug", page.Results[0].Name)
+			assert.NotNil(t, page.Results[0].StateDetail)
+			assert.Equal(t, 3, page.TotalCount)
+		}
+
+		var names []string
+		it := s.Iterate(context.Background(), fx.WorkspaceSlug, fx.ProjectID, opts)
+		for it.Next() {
+			names = append(names, it.Item().Name)
+		}
+		assert.NoError(t, it.Err())
+		assert.Equal(t, []string{"Urgent bug", "Open bug", "Closed bug"}, names)
+
+		it = s.Iterate(context.Background(), fx.WorkspaceSlug, fx.ProjectID, &IssueListOptions{Archived: IncludeArchived})
+		assert.False(t, it.Next())
+		assert.Error(t, it.Err())
+	})
+
+	t.Run("DateRange", func(t *testing.T) {
+		issues, err := s.List(fx.WorkspaceSlug, fx.ProjectID, &IssueListOptions{
+			TargetDate: &DateRange{After: "2024-01-01", Before: "2024-01-31"},
+		})
+		if assert.NoError(t, err) && assert.Len(t, issues, 1) {
+			assert.Equal(t, "Open bug", issues[0].Name)
+		}
+	})
+
+	t.Run("Expand", func(t *testing.T) {
+		issues, err := s.List(fx.WorkspaceSlug, fx.ProjectID, &IssueListOptions{
+			Priorities: []string{"urgent"},
+			Expand:     []string{ExpandState, ExpandAssignees},
+		})
+		if !assert.NoError(t, err) || !assert.Len(t, issues, 1) {
+			return
+		}
+		issue := issues[0]
+		assert.Equal(t, stateIDs[models.StateGroupUnstarted], issue.State)
+		if assert.NotNil(t, issue.StateDetail) {
+			assert.Equal(t, models.StateGroupUnstarted, issue.StateDetail.Group)
+		}
+		assert.Equal(t, []string{alice}, issue.Assignees)
+		if assert.Len(t, issue.AssigneeDetails, 1) {
+			assert.Equal(t, fx.Members[0].DisplayName, issue.AssigneeDetails[0].DisplayName)
+		}
+	})
+
+	t.Run("Fields", func(t *testing.T) {
+		issues, err := s.List(fx.WorkspaceSlug, fx.ProjectID, &IssueListOptions{
+			Priorities: []string{"urgent"},
+			Fields:     []string{"name"},
+		})
+		if assert.NoError(t, err) && assert.Len(t, issues, 1) {
+			assert.Equal(t, "Urgent bug", issues[0].Name)
+			assert.Empty(t, issues[0].Priority)
+		}
+	})
+}
+
+// TestIssuesServiceMarkdown tests creating and updating issues and comments with Markdown
+// 测试使用 Markdown 创建和更新问题及评论
+func TestIssuesServiceMarkdown(t *testing.T) {
+	env := newTestEnv(t)
+	if env.workspaceSlug == "" || env.projectID == "" {
+		t.Skip("Required environment variables not set")
+	}
+	ws, proj := env.workspaceSlug, env.projectID
+	s := NewIssuesService(env.client)
+
+	issue, err := s.Create(ws, proj, &IssueCreateRequest{
+		Name:                "Markdown issue",
+		DescriptionMarkdown: "Steps:\n\n- [ ] reproduce\n- [x] **report**",
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer s.Delete(ws, proj, issue.ID)
+	assert.Equal(t, `<p>Steps:</p><ul data-type="taskList"><li data-type="taskItem" data-checked="false"><p>reproduce</p></li><li data-type="taskItem" data-checked="true"><p><strong>report</strong></p></li></ul>`, issue.DescriptionHTML)
+	assert.Equal(t, "Steps:\nreproduce\nreport", issue.DescriptionStripped)
+
+	updated, err := s.Update(ws, proj, issue.ID, &IssueUpdateRequest{DescriptionHTML: "<p>Plain <em>HTML</em></p>"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "<p>Plain <em>HTML</em></p>", updated.DescriptionHTML)
+		assert.Equal(t, "Plain HTML", updated.DescriptionStripped)
+	}
+
+	comments := NewCommentsService(env.client)
+	request := &CommentRequest{Markdown: "Looks **good**"}
+	comment, err := comments.Create(ws, proj, issue.ID, request)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "<p>Looks <strong>good</strong></p>", comment.CommentHTML)
+	assert.Empty(t, request.Markdown)
+	assert.Equal(t, comment.CommentHTML, request.CommentHTML)
+
+	// 再次发送同一请求时不会重新转换 Markdown
+	request.CommentHTML = "<p>Edited</p>"
+	comment, err = comments.Update(ws, proj, issue.ID, comment.ID, request)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "<p>Edited</p>", comment.CommentHTML)
+	}
+}
+
+// TestIssuesServiceClearAssignees tests removing all assignees with ClearAssignees
+// 测试使用 ClearAssignees 移除所有分配人
+func TestIssuesServiceClearAssignees(t *testing.T) {
+	srv := planetest.NewServer()
+	defer srv.Close()
+	fx := srv.Seed()
+	issue := srv.AddIssue(fx.WorkspaceSlug, fx.ProjectID, models.Issue{Name: "Assigned", Assignees: []string{fx.Members[0].ID}})
+
+	c := client.NewClient(srv.APIKey)
+	c.SetBaseURL(srv.BaseURL())
+	var body string
+	c.Use(client.BeforeRequest(func(req *http.Request) error {
+		if req.Method == http.MethodPatch {
+			data, err := io.ReadAll(req.Body)
+			req.Body = io.NopCloser(bytes.NewReader(data))
+			body = string(data)
+			return err
+		}
+		return nil
+	}))
+	s := NewIssuesService(c)
+
+	updated, err := s.Update(fx.WorkspaceSlug, fx.ProjectID, issue.ID, &IssueUpdateRequest{ClearAssignees: true})
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `{"assignees":[]}`, body)
+		assert.Empty(t, updated.Assignees)
+	}
+
+	// 未设置的分配人不会被发送
+	_, err = s.Update(fx.WorkspaceSlug, fx.ProjectID, issue.ID, &IssueUpdateRequest{Name: "Renamed"})
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `{"name":"Renamed"}`, body)
+	}
+
+	updated, err = s.Update(fx.WorkspaceSlug, fx.ProjectID, issue.ID, &IssueUpdateRequest{Assignees: []string{fx.Members[1].ID}})
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `{"assignees":["`+fx.Members[1].ID+`"]}`, body)
+		assert.Equal(t, []string{fx.Members[1].ID}, updated.Assignees)
+	}
+}
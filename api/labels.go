@@ -1,7 +1,7 @@
 package api
 
 import (
-	"fmt"
+	"context"
 	"net/http"
 
 	"github.com/GeekWorkCode/plane-api-go/client"
@@ -10,16 +10,23 @@ import (
 
 // LabelsService handles communication with the label related endpoints
 type LabelsService struct {
-	client *client.Client
+	client   *client.Client
+	resolver *Resolver
 }
 
 // NewLabelsService creates a new labels service
 func NewLabelsService(client *client.Client) *LabelsService {
 	return &LabelsService{
-		client: client,
+		client:   client,
+		resolver: NewResolver(client, DefaultResolverTTL),
 	}
 }
 
+// SetResolver makes the service share a name resolver with other services
+func (s *LabelsService) SetResolver(resolver *Resolver) {
+	s.resolver = resolver
+}
+
 // LabelCreateRequest represents the request body for creating a label
 type LabelCreateRequest struct {
 	Name        string  `json:"name"`
@@ -38,25 +45,43 @@ type LabelUpdateRequest struct {
 
 // List returns all labels in a project
 func (s *LabelsService) List(workspaceSlug string, projectID string) ([]models.Label, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/labels/", workspaceSlug, projectID)
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
-	if err != nil {
-		return nil, err
-	}
+	return s.ListWithContext(context.Background(), workspaceSlug, projectID)
+}
 
-	response := new(models.LabelsResponse)
-	_, err = s.client.Do(req, response)
-	if err != nil {
-		return nil, err
-	}
+// ListWithContext returns all labels in a project, following pagination until every page is fetched
+func (s *LabelsService) ListWithContext(ctx context.Context, workspaceSlug string, projectID string) ([]models.Label, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/labels/", workspaceSlug, projectID)
+	results, err := listAll[models.Label](ctx, s.client, path, nil)
+	return results, err
+}
+
+// ListPage returns a single page of labels in a project
+func (s *LabelsService) ListPage(workspaceSlug string, projectID string, opts *ListOptions) (*models.PaginatedResponse[models.Label], error) {
+	return s.ListPageWithContext(context.Background(), workspaceSlug, projectID, opts)
+}
+
+// ListPageWithContext returns a single page of labels in a project
+func (s *LabelsService) ListPageWithContext(ctx context.Context, workspaceSlug string, projectID string, opts *ListOptions) (*models.PaginatedResponse[models.Label], error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/labels/", workspaceSlug, projectID)
+	page, err := listPage[models.Label](ctx, s.client, path, opts.values())
+	return page, err
+}
 
-	return response.Results, nil
+// Iterate returns an iterator over all labels in a project, starting at opts.Cursor
+func (s *LabelsService) Iterate(ctx context.Context, workspaceSlug string, projectID string, opts *ListOptions) *Iterator[models.Label] {
+	path := client.Pathf("/workspaces/%s/projects/%s/labels/", workspaceSlug, projectID)
+	return newListIterator[models.Label](ctx, s.client, path, opts.values())
 }
 
 // Get returns a label by its ID
 func (s *LabelsService) Get(workspaceSlug string, projectID string, labelID string) (*models.Label, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/labels/%s", workspaceSlug, projectID, labelID)
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	return s.GetWithContext(context.Background(), workspaceSlug, projectID, labelID)
+}
+
+// GetWithContext returns a label by its ID
+func (s *LabelsService) GetWithContext(ctx context.Context, workspaceSlug string, projectID string, labelID string) (*models.Label, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/labels/%s/", workspaceSlug, projectID, labelID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -68,38 +93,56 @@ func (s *LabelsService) Get(workspaceSlug string, projectID string, labelID stri
 
 // Create creates a new label
 func (s *LabelsService) Create(workspaceSlug string, projectID string, createRequest *LabelCreateRequest) (*models.Label, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/labels/", workspaceSlug, projectID)
-	req, err := s.client.NewRequest(http.MethodPost, path, createRequest)
+	return s.CreateWithContext(context.Background(), workspaceSlug, projectID, createRequest)
+}
+
+// CreateWithContext creates a new label
+func (s *LabelsService) CreateWithContext(ctx context.Context, workspaceSlug string, projectID string, createRequest *LabelCreateRequest) (*models.Label, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/labels/", workspaceSlug, projectID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, path, createRequest)
 	if err != nil {
 		return nil, err
 	}
 
 	label := new(models.Label)
 	_, err = s.client.Do(req, label)
+	s.resolver.invalidate(kindLabels, workspaceSlug, projectID)
 	return label, err
 }
 
 // Update updates a label
 func (s *LabelsService) Update(workspaceSlug string, projectID string, labelID string, updateRequest *LabelUpdateRequest) (*models.Label, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/labels/%s", workspaceSlug, projectID, labelID)
-	req, err := s.client.NewRequest(http.MethodPatch, path, updateRequest)
+	return s.UpdateWithContext(context.Background(), workspaceSlug, projectID, labelID, updateRequest)
+}
+
+// UpdateWithContext updates a label
+func (s *LabelsService) UpdateWithContext(ctx context.Context, workspaceSlug string, projectID string, labelID string, updateRequest *LabelUpdateRequest) (*models.Label, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/labels/%s/", workspaceSlug, projectID, labelID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPatch, path, updateRequest)
 	if err != nil {
 		return nil, err
 	}
 
 	label := new(models.Label)
 	_, err = s.client.Do(req, label)
+	s.resolver.invalidate(kindLabels, workspaceSlug, projectID)
 	return label, err
 }
 
 // Delete deletes a label
 func (s *LabelsService) Delete(workspaceSlug string, projectID string, labelID string) error {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/labels/%s", workspaceSlug, projectID, labelID)
-	req, err := s.client.NewRequest(http.MethodDelete, path, nil)
+	return s.DeleteWithContext(context.Background(), workspaceSlug, projectID, labelID)
+}
+
+// DeleteWithContext deletes a label
+func (s *LabelsService) DeleteWithContext(ctx context.Context, workspaceSlug string, projectID string, labelID string) error {
+	path := client.Pathf("/workspaces/%s/projects/%s/labels/%s/", workspaceSlug, projectID, labelID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodDelete, path, nil)
 	if err != nil {
 		return err
 	}
 
 	_, err = s.client.Do(req, nil)
+	s.resolver.invalidate(kindLabels, workspaceSlug, projectID)
 	return err
 }
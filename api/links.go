@@ -1,7 +1,7 @@
 package api
 
 import (
-	"fmt"
+	"context"
 	"net/http"
 
 	"github.com/GeekWorkCode/plane-api-go/client"
@@ -34,25 +34,43 @@ type LinkUpdateRequest struct {
 
 // List returns all links for an issue
 func (s *LinksService) List(workspaceSlug string, projectID string, issueID string) ([]models.Link, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/issues/%s/links/", workspaceSlug, projectID, issueID)
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
-	if err != nil {
-		return nil, err
-	}
+	return s.ListWithContext(context.Background(), workspaceSlug, projectID, issueID)
+}
 
-	response := new(models.LinksResponse)
-	_, err = s.client.Do(req, response)
-	if err != nil {
-		return nil, err
-	}
+// ListWithContext returns all links for an issue, following pagination until every page is fetched
+func (s *LinksService) ListWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string) ([]models.Link, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/links/", workspaceSlug, projectID, issueID)
+	results, err := listAll[models.Link](ctx, s.client, path, nil)
+	return results, err
+}
+
+// ListPage returns a single page of links for an issue
+func (s *LinksService) ListPage(workspaceSlug string, projectID string, issueID string, opts *ListOptions) (*models.PaginatedResponse[models.Link], error) {
+	return s.ListPageWithContext(context.Background(), workspaceSlug, projectID, issueID, opts)
+}
 
-	return response.Results, nil
+// ListPageWithContext returns a single page of links for an issue
+func (s *LinksService) ListPageWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, opts *ListOptions) (*models.PaginatedResponse[models.Link], error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/links/", workspaceSlug, projectID, issueID)
+	page, err := listPage[models.Link](ctx, s.client, path, opts.values())
+	return page, err
+}
+
+// Iterate returns an iterator over all links for an issue, starting at opts.Cursor
+func (s *LinksService) Iterate(ctx context.Context, workspaceSlug string, projectID string, issueID string, opts *ListOptions) *Iterator[models.Link] {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/links/", workspaceSlug, projectID, issueID)
+	return newListIterator[models.Link](ctx, s.client, path, opts.values())
 }
 
 // Get returns a link by its ID
 func (s *LinksService) Get(workspaceSlug string, projectID string, issueID string, linkID string) (*models.Link, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/issues/%s/links/%s", workspaceSlug, projectID, issueID, linkID)
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	return s.GetWithContext(context.Background(), workspaceSlug, projectID, issueID, linkID)
+}
+
+// GetWithContext returns a link by its ID
+func (s *LinksService) GetWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, linkID string) (*models.Link, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/links/%s/", workspaceSlug, projectID, issueID, linkID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -64,8 +82,13 @@ func (s *LinksService) Get(workspaceSlug string, projectID string, issueID strin
 
 // Create creates a new link
 func (s *LinksService) Create(workspaceSlug string, projectID string, issueID string, createRequest *LinkCreateRequest) (*models.Link, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/issues/%s/links/", workspaceSlug, projectID, issueID)
-	req, err := s.client.NewRequest(http.MethodPost, path, createRequest)
+	return s.CreateWithContext(context.Background(), workspaceSlug, projectID, issueID, createRequest)
+}
+
+// CreateWithContext creates a new link
+func (s *LinksService) CreateWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, createRequest *LinkCreateRequest) (*models.Link, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/links/", workspaceSlug, projectID, issueID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, path, createRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -77,8 +100,13 @@ func (s *LinksService) Create(workspaceSlug string, projectID string, issueID st
 
 // Update updates a link
 func (s *LinksService) Update(workspaceSlug string, projectID string, issueID string, linkID string, updateRequest *LinkUpdateRequest) (*models.Link, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/issues/%s/links/%s", workspaceSlug, projectID, issueID, linkID)
-	req, err := s.client.NewRequest(http.MethodPatch, path, updateRequest)
+	return s.UpdateWithContext(context.Background(), workspaceSlug, projectID, issueID, linkID, updateRequest)
+}
+
+// UpdateWithContext updates a link
+func (s *LinksService) UpdateWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, linkID string, updateRequest *LinkUpdateRequest) (*models.Link, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/links/%s/", workspaceSlug, projectID, issueID, linkID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPatch, path, updateRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -90,8 +118,13 @@ func (s *LinksService) Update(workspaceSlug string, projectID string, issueID st
 
 // Delete deletes a link
 func (s *LinksService) Delete(workspaceSlug string, projectID string, issueID string, linkID string) error {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/issues/%s/links/%s", workspaceSlug, projectID, issueID, linkID)
-	req, err := s.client.NewRequest(http.MethodDelete, path, nil)
+	return s.DeleteWithContext(context.Background(), workspaceSlug, projectID, issueID, linkID)
+}
+
+// DeleteWithContext deletes a link
+func (s *LinksService) DeleteWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, linkID string) error {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/links/%s/", workspaceSlug, projectID, issueID, linkID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodDelete, path, nil)
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -10,16 +11,23 @@ import (
 
 // MembersService handles communication with the project members related endpoints
 type MembersService struct {
-	client *client.Client
+	client   *client.Client
+	resolver *Resolver
 }
 
 // NewMembersService creates a new members service
 func NewMembersService(client *client.Client) *MembersService {
 	return &MembersService{
-		client: client,
+		client:   client,
+		resolver: NewResolver(client, DefaultResolverTTL),
 	}
 }
 
+// SetResolver makes the service share a name resolver with other services
+func (s *MembersService) SetResolver(resolver *Resolver) {
+	s.resolver = resolver
+}
+
 // MemberUserResponse represents the simplified user response from the members endpoint
 type MemberUserResponse struct {
 	ID          string      `json:"id"`
@@ -33,8 +41,13 @@ type MemberUserResponse struct {
 
 // List returns all members for a project
 func (s *MembersService) List(workspaceSlug string, projectID string) ([]models.Member, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/members/", workspaceSlug, projectID)
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	return s.ListWithContext(context.Background(), workspaceSlug, projectID)
+}
+
+// ListWithContext returns all members for a project
+func (s *MembersService) ListWithContext(ctx context.Context, workspaceSlug string, projectID string) ([]models.Member, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/members/", workspaceSlug, projectID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, fmt.Errorf("创建请求失败: %w", err)
 	}
@@ -76,9 +89,14 @@ func (s *MembersService) List(workspaceSlug string, projectID string) ([]models.
 
 // Get returns a member by its ID
 func (s *MembersService) Get(workspaceSlug string, projectID string, memberID string) (*models.Member, error) {
+	return s.GetWithContext(context.Background(), workspaceSlug, projectID, memberID)
+}
+
+// GetWithContext returns a member by its ID
+func (s *MembersService) GetWithContext(ctx context.Context, workspaceSlug string, projectID string, memberID string) (*models.Member, error) {
 	// Since direct member lookup is returning 404, we'll use the List method to get all members
 	// and then filter to find the one with the requested ID
-	members, err := s.List(workspaceSlug, projectID)
+	members, err := s.ListWithContext(ctx, workspaceSlug, projectID)
 	if err != nil {
 		return nil, fmt.Errorf("获取成员列表失败: %w", err)
 	}
@@ -90,5 +108,39 @@ func (s *MembersService) Get(workspaceSlug string, projectID string, memberID st
 		}
 	}
 
-	return nil, fmt.Errorf("成员未找到: %s", memberID)
+	return nil, fmt.Errorf("成员未找到: %s: %w", memberID, client.ErrNotFound)
+}
+
+// GetByName returns the project member with the given display name, full name or email
+func (s *MembersService) GetByName(workspaceSlug string, projectID string, name string) (*models.Member, error) {
+	return s.GetByNameWithContext(context.Background(), workspaceSlug, projectID, name)
+}
+
+// GetByNameWithContext returns the project member with the given display name, full
+// name or email, matched like assignee names through the shared name resolver.
+// Unknown and ambiguous names return a NameNotFoundError or an AmbiguousNameError.
+func (s *MembersService) GetByNameWithContext(ctx context.Context, workspaceSlug string, projectID string, name string) (*models.Member, error) {
+	member, err := memberByName(ctx, s.resolver, workspaceSlug, projectID, name)
+	if err != nil {
+		return nil, fmt.Errorf("查找成员失败: %w", err)
+	}
+	return member, nil
+}
+
+// memberByName returns the project member with the given name from the cached members
+func memberByName(ctx context.Context, resolver *Resolver, workspaceSlug string, projectID string, name string) (*models.Member, error) {
+	id, err := resolver.MemberID(ctx, workspaceSlug, projectID, name)
+	if err != nil {
+		return nil, err
+	}
+	members, err := resolver.Members(ctx, workspaceSlug, projectID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range members {
+		if members[i].Member.ID == id {
+			return &members[i], nil
+		}
+	}
+	return nil, fmt.Errorf("成员未找到: %s: %w", name, client.ErrNotFound)
 }
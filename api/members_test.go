@@ -1,10 +1,12 @@
 package api
 
 import (
-	"os"
+	"errors"
 	"testing"
 
 	"github.com/GeekWorkCode/plane-api-go/client"
+	"github.com/GeekWorkCode/plane-api-go/models"
+	"github.com/GeekWorkCode/plane-api-go/planetest"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -13,17 +15,14 @@ import (
 func TestMembersService(t *testing.T) {
 	// Initialize test client
 	// 初始化测试客户端
-	apiKey := os.Getenv("PLANE_API_KEY")
-	if apiKey == "" {
-		t.Skip("PLANE_API_KEY not set")
-	}
-	c := client.NewClient(apiKey)
+	env := newTestEnv(t)
+	c := env.client
 	s := NewMembersService(c)
 
 	// Test data
 	// 测试数据
-	workspaceSlug := os.Getenv("PLANE_WORKSPACE_SLUG")
-	projectID := os.Getenv("PLANE_PROJECT_ID")
+	workspaceSlug := env.workspaceSlug
+	projectID := env.projectID
 
 	if workspaceSlug == "" || projectID == "" {
 		t.Skip("Required environment variables not set")
@@ -56,3 +55,34 @@ func TestMembersService(t *testing.T) {
 		}
 	})
 }
+
+// TestMembersServiceGetByName tests finding members by display name, full name and email
+// 测试通过显示名称、姓名和邮箱查找成员
+func TestMembersServiceGetByName(t *testing.T) {
+	srv := planetest.NewServer()
+	defer srv.Close()
+	fx := srv.Seed()
+
+	c := client.NewClient(srv.APIKey)
+	c.SetBaseURL(srv.BaseURL())
+	s := NewMembersService(c)
+
+	alice := fx.Members[0]
+	for _, name := range []string{alice.DisplayName, "alice smith", alice.Email} {
+		member, err := s.GetByName(fx.WorkspaceSlug, fx.ProjectID, name)
+		if assert.NoError(t, err, name) {
+			assert.Equal(t, alice.ID, member.Member.ID)
+		}
+	}
+
+	_, err := s.GetByName(fx.WorkspaceSlug, fx.ProjectID, "alcie")
+	var notFound *NameNotFoundError
+	if assert.True(t, errors.As(err, &notFound)) && assert.NotEmpty(t, notFound.Suggestions) {
+		assert.Equal(t, alice.ID, notFound.Suggestions[0].ID)
+	}
+
+	srv.AddMember(fx.ProjectID, models.MemberUser{DisplayName: "alice", Email: "alice@other.example.com"})
+	s.resolver.Invalidate(fx.WorkspaceSlug, fx.ProjectID)
+	_, err = s.GetByName(fx.WorkspaceSlug, fx.ProjectID, "alice")
+	assert.True(t, errors.Is(err, ErrAmbiguousName))
+}
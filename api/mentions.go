@@ -0,0 +1,186 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/GeekWorkCode/plane-api-go/markdown"
+	"github.com/GeekWorkCode/plane-api-go/models"
+)
+
+// Mention returns the mention markup of the project member with the given display
+// name, full name or email, for use in the HTML of comments and descriptions
+func (s *MembersService) Mention(workspaceSlug string, projectID string, name string) (string, error) {
+	return s.MentionWithContext(context.Background(), workspaceSlug, projectID, name)
+}
+
+// MentionWithContext returns the mention markup of the project member with the given
+// display name, full name or email. Unknown and ambiguous names return a
+// NameNotFoundError or an AmbiguousNameError.
+func (s *MembersService) MentionWithContext(ctx context.Context, workspaceSlug string, projectID string, name string) (string, error) {
+	mention, err := mentionOf(ctx, s.resolver, workspaceSlug, projectID, name)
+	if err != nil {
+		return "", fmt.Errorf("查找提及的成员失败: %w", err)
+	}
+	return mention, nil
+}
+
+// ExpandMentions replaces @name and @email in HTML with mentions of the project
+// members they name
+func (s *MembersService) ExpandMentions(workspaceSlug string, projectID string, html string) (string, error) {
+	return s.ExpandMentionsWithContext(context.Background(), workspaceSlug, projectID, html)
+}
+
+// ExpandMentionsWithContext replaces @name and @email in HTML with mentions of the
+// project members they name. Names are matched like MemberID, without spaces.
+// Unknown names are left as they are, and text in code, links and existing
+// mentions is not changed.
+func (s *MembersService) ExpandMentionsWithContext(ctx context.Context, workspaceSlug string, projectID string, html string) (string, error) {
+	return expandMentions(ctx, s.resolver, workspaceSlug, projectID, html)
+}
+
+// MentionedMembers returns the project members mentioned in HTML such as the
+// CommentHTML of a comment, e.g. to notify them
+func (s *MembersService) MentionedMembers(workspaceSlug string, projectID string, html string) ([]models.MemberUser, error) {
+	return s.MentionedMembersWithContext(context.Background(), workspaceSlug, projectID, html)
+}
+
+// MentionedMembersWithContext returns the project members mentioned in HTML in the
+// order they are first mentioned. Mentions of users who are no longer members of
+// the project are skipped.
+func (s *MembersService) MentionedMembersWithContext(ctx context.Context, workspaceSlug string, projectID string, html string) ([]models.MemberUser, error) {
+	ids := markdown.Mentions(html)
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	members, err := s.resolver.Members(ctx, workspaceSlug, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("获取成员列表失败: %w", err)
+	}
+	byID := make(map[string]models.MemberUser, len(members))
+	for _, member := range members {
+		byID[member.Member.ID] = member.Member
+	}
+
+	var mentioned []models.MemberUser
+	for _, id := range ids {
+		if user, ok := byID[id]; ok {
+			mentioned = append(mentioned, user)
+		}
+	}
+	return mentioned, nil
+}
+
+// mentionOf returns the mention markup of the member with the given name, labelled
+// with the display name of the member
+func mentionOf(ctx context.Context, resolver *Resolver, workspaceSlug string, projectID string, name string) (string, error) {
+	member, err := memberByName(ctx, resolver, workspaceSlug, projectID, name)
+	if err != nil {
+		return "", err
+	}
+	return markdown.Mention(member.Member.ID, member.Member.DisplayName), nil
+}
+
+var (
+	// htmlTagPattern matches a start or end tag
+	htmlTagPattern = regexp.MustCompile(`<(/?)([a-zA-Z][a-zA-Z0-9-]*)[^>]*>`)
+	// atMentionPattern matches @name or @email at the start of text or after a
+	// space, an opening bracket or a non-breaking space
+	atMentionPattern = regexp.MustCompile(`(^|[\s(\[]|&nbsp;)@([\p{L}\p{N}_.+-]+(?:@[\p{L}\p{N}-]+(?:\.[\p{L}\p{N}-]+)+)?)`)
+)
+
+// mentionSkipTags are the elements whose text is not searched for mentions
+var mentionSkipTags = map[string]bool{
+	"a":                 true,
+	"code":              true,
+	"pre":               true,
+	"mention-component": true,
+}
+
+// expandMentions replaces @name and @email in the text of HTML with mentions
+func expandMentions(ctx context.Context, resolver *Resolver, workspaceSlug string, projectID string, html string) (string, error) {
+	// 同一名称只解析一次, 未知名称也不会重复获取成员列表
+	resolved := map[string]string{}
+	replace := func(text string) (string, error) {
+		var b strings.Builder
+		last := 0
+		for _, m := range atMentionPattern.FindAllStringSubmatchIndex(text, -1) {
+			name := text[m[4]:m[5]]
+			// 句末的点不属于名称
+			end := m[5] - (len(name) - len(strings.TrimRight(name, ".")))
+			name = text[m[4]:end]
+			if name == "" {
+				continue
+			}
+
+			mention, ok := resolved[name]
+			if !ok {
+				var err error
+				mention, err = mentionOf(ctx, resolver, workspaceSlug, projectID, name)
+				var notFound *NameNotFoundError
+				if errors.As(err, &notFound) {
+					mention, err = "", nil
+				}
+				if err != nil {
+					return "", fmt.Errorf("解析提及 '@%s' 失败: %w", name, err)
+				}
+				resolved[name] = mention
+			}
+			if mention == "" {
+				continue
+			}
+			b.WriteString(text[last:m[3]])
+			b.WriteString(mention)
+			last = end
+		}
+		b.WriteString(text[last:])
+		return b.String(), nil
+	}
+
+	var b strings.Builder
+	skip := map[string]int{}
+	skipping := func() bool {
+		for _, depth := range skip {
+			if depth > 0 {
+				return true
+			}
+		}
+		return false
+	}
+	last := 0
+	for _, m := range htmlTagPattern.FindAllStringSubmatchIndex(html, -1) {
+		text := html[last:m[0]]
+		if !skipping() {
+			var err error
+			if text, err = replace(text); err != nil {
+				return "", err
+			}
+		}
+		b.WriteString(text)
+		b.WriteString(html[m[0]:m[1]])
+		last = m[1]
+
+		tag := strings.ToLower(html[m[4]:m[5]])
+		if mentionSkipTags[tag] {
+			if m[3] > m[2] {
+				if skip[tag] > 0 {
+					skip[tag]--
+				}
+			} else if !strings.HasSuffix(html[m[0]:m[1]], "/>") {
+				skip[tag]++
+			}
+		}
+	}
+	text := html[last:]
+	if !skipping() {
+		var err error
+		if text, err = replace(text); err != nil {
+			return "", err
+		}
+	}
+	b.WriteString(text)
+	return b.String(), nil
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/GeekWorkCode/plane-api-go/client"
+	"github.com/GeekWorkCode/plane-api-go/markdown"
+	"github.com/GeekWorkCode/plane-api-go/models"
+	"github.com/GeekWorkCode/plane-api-go/planetest"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMembersServiceMentions tests building, expanding and extracting mentions of members
+// 测试构建、展开和提取成员提及
+func TestMembersServiceMentions(t *testing.T) {
+	srv := planetest.NewServer()
+	defer srv.Close()
+	fx := srv.Seed()
+	ws, proj := fx.WorkspaceSlug, fx.ProjectID
+	alice, bob := fx.Members[0], fx.Members[1]
+
+	c := client.NewClient(srv.APIKey)
+	c.SetBaseURL(srv.BaseURL())
+	s := NewMembersService(c)
+	aliceMention := markdown.Mention(alice.ID, alice.DisplayName)
+	bobMention := markdown.Mention(bob.ID, bob.DisplayName)
+
+	t.Run("Mention", func(t *testing.T) {
+		for _, name := range []string{alice.DisplayName, "Alice Smith", alice.Email} {
+			mention, err := s.Mention(ws, proj, name)
+			if assert.NoError(t, err, name) {
+				assert.Equal(t, aliceMention, mention)
+			}
+		}
+
+		_, err := s.Mention(ws, proj, "nobody")
+		var notFound *NameNotFoundError
+		assert.True(t, errors.As(err, &notFound))
+	})
+
+	t.Run("ExpandMentions", func(t *testing.T) {
+		html, err := s.ExpandMentions(ws, proj,
+			"<p>@alice and (@bob@example.com), ping @alice. Not @nobody, a@alice or <code>@alice</code></p>")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "<p>"+aliceMention+" and ("+bobMention+"), ping "+aliceMention+". Not @nobody, a@alice or <code>@alice</code></p>", html)
+		}
+
+		// 已有的提及保持不变
+		again, err := s.ExpandMentions(ws, proj, html)
+		assert.NoError(t, err)
+		assert.Equal(t, html, again)
+	})
+
+	t.Run("Ambiguous", func(t *testing.T) {
+		srv.AddMember(proj, models.MemberUser{DisplayName: "sam", Email: "sam@example.com"})
+		srv.AddMember(proj, models.MemberUser{DisplayName: "sam", Email: "sam@other.example.com"})
+		s.resolver.Invalidate(ws, proj)
+
+		_, err := s.ExpandMentions(ws, proj, "<p>@sam</p>")
+		assert.True(t, errors.Is(err, ErrAmbiguousName))
+
+		html, err := s.ExpandMentions(ws, proj, "<p>@sam@other.example.com</p>")
+		assert.NoError(t, err)
+		assert.Contains(t, html, `label="sam"`)
+	})
+
+	t.Run("MentionedMembers", func(t *testing.T) {
+		html := "<p>" + bobMention + " " + aliceMention + " " + bobMention + markdown.Mention("former-member", "") + "</p>"
+		members, err := s.MentionedMembers(ws, proj, html)
+		if assert.NoError(t, err) && assert.Len(t, members, 2) {
+			assert.Equal(t, bob.ID, members[0].ID)
+			assert.Equal(t, alice.ID, members[1].ID)
+		}
+	})
+}
+
+// TestExpandMentionsOnCreate tests creating comments and issues that mention members by name
+// 测试创建通过名称提及成员的评论和问题
+func TestExpandMentionsOnCreate(t *testing.T) {
+	srv := planetest.NewServer()
+	defer srv.Close()
+	fx := srv.Seed()
+	ws, proj := fx.WorkspaceSlug, fx.ProjectID
+	alice, bob := fx.Members[0], fx.Members[1]
+
+	c := client.NewClient(srv.APIKey)
+	c.SetBaseURL(srv.BaseURL())
+
+	comment, err := NewCommentsService(c).Create(ws, proj, fx.IssueID, &CommentRequest{
+		Markdown:       "@bob please review, cc @alice@example.com",
+		ExpandMentions: true,
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{bob.ID, alice.ID}, markdown.Mentions(comment.CommentHTML))
+	}
+
+	issue, err := NewIssuesService(c).Create(ws, proj, &IssueCreateRequest{
+		Name:                "Mentions",
+		DescriptionMarkdown: "Owner: @alice",
+		ExpandMentions:      true,
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{alice.ID}, markdown.Mentions(issue.DescriptionHTML))
+		assert.Equal(t, "Owner: @alice", issue.DescriptionStripped)
+	}
+}
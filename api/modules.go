@@ -1,7 +1,7 @@
 package api
 
 import (
-	"fmt"
+	"context"
 	"net/http"
 
 	"github.com/GeekWorkCode/plane-api-go/client"
@@ -10,16 +10,23 @@ import (
 
 // ModulesService handles communication with the module related endpoints
 type ModulesService struct {
-	client *client.Client
+	client   *client.Client
+	resolver *Resolver
 }
 
 // NewModulesService creates a new modules service
 func NewModulesService(client *client.Client) *ModulesService {
 	return &ModulesService{
-		client: client,
+		client:   client,
+		resolver: NewResolver(client, DefaultResolverTTL),
 	}
 }
 
+// SetResolver makes the service share a name resolver with other services
+func (s *ModulesService) SetResolver(resolver *Resolver) {
+	s.resolver = resolver
+}
+
 // ModuleCreateRequest represents the request body for creating a module
 type ModuleCreateRequest struct {
 	Name        string `json:"name"`
@@ -34,21 +41,43 @@ type ModuleUpdateRequest struct {
 
 // List returns all modules in a project
 func (s *ModulesService) List(workspaceSlug string, projectID string) ([]models.Module, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/modules/", workspaceSlug, projectID)
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
-	if err != nil {
-		return nil, err
-	}
+	return s.ListWithContext(context.Background(), workspaceSlug, projectID)
+}
+
+// ListWithContext returns all modules in a project, following pagination until every page is fetched
+func (s *ModulesService) ListWithContext(ctx context.Context, workspaceSlug string, projectID string) ([]models.Module, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/modules/", workspaceSlug, projectID)
+	results, err := listAll[models.Module](ctx, s.client, path, nil)
+	return results, err
+}
 
-	var modules []models.Module
-	_, err = s.client.Do(req, &modules)
-	return modules, err
+// ListPage returns a single page of modules in a project
+func (s *ModulesService) ListPage(workspaceSlug string, projectID string, opts *ListOptions) (*models.PaginatedResponse[models.Module], error) {
+	return s.ListPageWithContext(context.Background(), workspaceSlug, projectID, opts)
+}
+
+// ListPageWithContext returns a single page of modules in a project
+func (s *ModulesService) ListPageWithContext(ctx context.Context, workspaceSlug string, projectID string, opts *ListOptions) (*models.PaginatedResponse[models.Module], error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/modules/", workspaceSlug, projectID)
+	page, err := listPage[models.Module](ctx, s.client, path, opts.values())
+	return page, err
+}
+
+// Iterate returns an iterator over all modules in a project, starting at opts.Cursor
+func (s *ModulesService) Iterate(ctx context.Context, workspaceSlug string, projectID string, opts *ListOptions) *Iterator[models.Module] {
+	path := client.Pathf("/workspaces/%s/projects/%s/modules/", workspaceSlug, projectID)
+	return newListIterator[models.Module](ctx, s.client, path, opts.values())
 }
 
 // Get returns a module by its ID
 func (s *ModulesService) Get(workspaceSlug string, projectID string, moduleID string) (*models.Module, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/modules/%s", workspaceSlug, projectID, moduleID)
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	return s.GetWithContext(context.Background(), workspaceSlug, projectID, moduleID)
+}
+
+// GetWithContext returns a module by its ID
+func (s *ModulesService) GetWithContext(ctx context.Context, workspaceSlug string, projectID string, moduleID string) (*models.Module, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/modules/%s/", workspaceSlug, projectID, moduleID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -60,39 +89,57 @@ func (s *ModulesService) Get(workspaceSlug string, projectID string, moduleID st
 
 // Create creates a new module
 func (s *ModulesService) Create(workspaceSlug string, projectID string, createRequest *ModuleCreateRequest) (*models.Module, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/modules/", workspaceSlug, projectID)
-	req, err := s.client.NewRequest(http.MethodPost, path, createRequest)
+	return s.CreateWithContext(context.Background(), workspaceSlug, projectID, createRequest)
+}
+
+// CreateWithContext creates a new module
+func (s *ModulesService) CreateWithContext(ctx context.Context, workspaceSlug string, projectID string, createRequest *ModuleCreateRequest) (*models.Module, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/modules/", workspaceSlug, projectID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, path, createRequest)
 	if err != nil {
 		return nil, err
 	}
 
 	module := new(models.Module)
 	_, err = s.client.Do(req, module)
+	s.resolver.invalidate(kindModules, workspaceSlug, projectID)
 	return module, err
 }
 
 // Update updates a module
 func (s *ModulesService) Update(workspaceSlug string, projectID string, moduleID string, updateRequest *ModuleUpdateRequest) (*models.Module, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/modules/%s", workspaceSlug, projectID, moduleID)
-	req, err := s.client.NewRequest(http.MethodPatch, path, updateRequest)
+	return s.UpdateWithContext(context.Background(), workspaceSlug, projectID, moduleID, updateRequest)
+}
+
+// UpdateWithContext updates a module
+func (s *ModulesService) UpdateWithContext(ctx context.Context, workspaceSlug string, projectID string, moduleID string, updateRequest *ModuleUpdateRequest) (*models.Module, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/modules/%s/", workspaceSlug, projectID, moduleID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPatch, path, updateRequest)
 	if err != nil {
 		return nil, err
 	}
 
 	module := new(models.Module)
 	_, err = s.client.Do(req, module)
+	s.resolver.invalidate(kindModules, workspaceSlug, projectID)
 	return module, err
 }
 
 // Delete deletes a module
 func (s *ModulesService) Delete(workspaceSlug string, projectID string, moduleID string) error {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/modules/%s", workspaceSlug, projectID, moduleID)
-	req, err := s.client.NewRequest(http.MethodDelete, path, nil)
+	return s.DeleteWithContext(context.Background(), workspaceSlug, projectID, moduleID)
+}
+
+// DeleteWithContext deletes a module
+func (s *ModulesService) DeleteWithContext(ctx context.Context, workspaceSlug string, projectID string, moduleID string) error {
+	path := client.Pathf("/workspaces/%s/projects/%s/modules/%s/", workspaceSlug, projectID, moduleID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodDelete, path, nil)
 	if err != nil {
 		return err
 	}
 
 	_, err = s.client.Do(req, nil)
+	s.resolver.invalidate(kindModules, workspaceSlug, projectID)
 	return err
 }
 
@@ -103,22 +150,44 @@ type ModuleIssueAddRequest struct {
 
 // ListIssues returns all issues in a module
 func (s *ModulesService) ListIssues(workspaceSlug string, projectID string, moduleID string) ([]models.Issue, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/modules/%s/module-issues/", workspaceSlug, projectID, moduleID)
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
-	if err != nil {
-		return nil, err
-	}
+	return s.ListIssuesWithContext(context.Background(), workspaceSlug, projectID, moduleID)
+}
+
+// ListIssuesWithContext returns all issues in a module, following pagination until every page is fetched
+func (s *ModulesService) ListIssuesWithContext(ctx context.Context, workspaceSlug string, projectID string, moduleID string) ([]models.Issue, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/modules/%s/module-issues/", workspaceSlug, projectID, moduleID)
+	results, err := listAll[models.Issue](ctx, s.client, path, nil)
+	return results, err
+}
 
-	var issues []models.Issue
-	_, err = s.client.Do(req, &issues)
-	return issues, err
+// ListIssuesPage returns a single page of issues in a module
+func (s *ModulesService) ListIssuesPage(workspaceSlug string, projectID string, moduleID string, opts *ListOptions) (*models.PaginatedResponse[models.Issue], error) {
+	return s.ListIssuesPageWithContext(context.Background(), workspaceSlug, projectID, moduleID, opts)
+}
+
+// ListIssuesPageWithContext returns a single page of issues in a module
+func (s *ModulesService) ListIssuesPageWithContext(ctx context.Context, workspaceSlug string, projectID string, moduleID string, opts *ListOptions) (*models.PaginatedResponse[models.Issue], error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/modules/%s/module-issues/", workspaceSlug, projectID, moduleID)
+	page, err := listPage[models.Issue](ctx, s.client, path, opts.values())
+	return page, err
+}
+
+// IterateIssues returns an iterator over all issues in a module, starting at opts.Cursor
+func (s *ModulesService) IterateIssues(ctx context.Context, workspaceSlug string, projectID string, moduleID string, opts *ListOptions) *Iterator[models.Issue] {
+	path := client.Pathf("/workspaces/%s/projects/%s/modules/%s/module-issues/", workspaceSlug, projectID, moduleID)
+	return newListIterator[models.Issue](ctx, s.client, path, opts.values())
 }
 
 // AddIssues adds issues to a module
 func (s *ModulesService) AddIssues(workspaceSlug string, projectID string, moduleID string, issueIDs []string) error {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/modules/%s/module-issues/", workspaceSlug, projectID, moduleID)
+	return s.AddIssuesWithContext(context.Background(), workspaceSlug, projectID, moduleID, issueIDs)
+}
+
+// AddIssuesWithContext adds issues to a module
+func (s *ModulesService) AddIssuesWithContext(ctx context.Context, workspaceSlug string, projectID string, moduleID string, issueIDs []string) error {
+	path := client.Pathf("/workspaces/%s/projects/%s/modules/%s/module-issues/", workspaceSlug, projectID, moduleID)
 
-	req, err := s.client.NewRequest(http.MethodPost, path, &ModuleIssueAddRequest{
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, path, &ModuleIssueAddRequest{
 		Issues: issueIDs,
 	})
 	if err != nil {
@@ -131,8 +200,13 @@ func (s *ModulesService) AddIssues(workspaceSlug string, projectID string, modul
 
 // RemoveIssue removes an issue from a module
 func (s *ModulesService) RemoveIssue(workspaceSlug string, projectID string, moduleID string, issueID string) error {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/modules/%s/module-issues/%s", workspaceSlug, projectID, moduleID, issueID)
-	req, err := s.client.NewRequest(http.MethodDelete, path, nil)
+	return s.RemoveIssueWithContext(context.Background(), workspaceSlug, projectID, moduleID, issueID)
+}
+
+// RemoveIssueWithContext removes an issue from a module
+func (s *ModulesService) RemoveIssueWithContext(ctx context.Context, workspaceSlug string, projectID string, moduleID string, issueID string) error {
+	path := client.Pathf("/workspaces/%s/projects/%s/modules/%s/module-issues/%s/", workspaceSlug, projectID, moduleID, issueID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodDelete, path, nil)
 	if err != nil {
 		return err
 	}
@@ -1,10 +1,8 @@
 package api
 
 import (
-	"os"
 	"testing"
 
-	"github.com/GeekWorkCode/plane-api-go/client"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -13,17 +11,14 @@ import (
 func TestModulesService(t *testing.T) {
 	// Initialize test client
 	// 初始化测试客户端
-	apiKey := os.Getenv("PLANE_API_KEY")
-	if apiKey == "" {
-		t.Skip("PLANE_API_KEY not set")
-	}
-	c := client.NewClient(apiKey)
+	env := newTestEnv(t)
+	c := env.client
 	s := NewModulesService(c)
 
 	// Test data
 	// 测试数据
-	workspaceSlug := os.Getenv("PLANE_WORKSPACE_SLUG")
-	projectID := os.Getenv("PLANE_PROJECT_ID")
+	workspaceSlug := env.workspaceSlug
+	projectID := env.projectID
 
 	if workspaceSlug == "" || projectID == "" {
 		t.Skip("Required environment variables not set")
@@ -0,0 +1,205 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/GeekWorkCode/plane-api-go/client"
+)
+
+// ErrAmbiguousName is wrapped by AmbiguousNameError
+var ErrAmbiguousName = errors.New("plane: name matches several objects")
+
+// maxSuggestions is the number of suggestions a NameNotFoundError holds at most
+const maxSuggestions = 5
+
+// NameCandidate is an object a name given to a lookup may refer to
+type NameCandidate struct {
+	ID     string
+	Name   string
+	Detail string // e.g. the email of a member
+}
+
+func (c NameCandidate) String() string {
+	if c.Detail == "" {
+		return fmt.Sprintf("'%s'", c.Name)
+	}
+	return fmt.Sprintf("'%s' (%s)", c.Name, c.Detail)
+}
+
+// AmbiguousNameError is returned when a name matches several objects, e.g. two
+// members with the same display name. It lists all of them.
+// 名称匹配到多个对象
+type AmbiguousNameError struct {
+	Kind       string // state, member, label, cycle or module
+	Name       string
+	Candidates []NameCandidate
+}
+
+// Error implements the error interface
+func (e *AmbiguousNameError) Error() string {
+	return fmt.Sprintf("名称 '%s' 匹配到多个%s: %s", e.Name, kindNames[e.Kind], joinCandidates(e.Candidates))
+}
+
+// Unwrap returns ErrAmbiguousName
+func (e *AmbiguousNameError) Unwrap() error {
+	return ErrAmbiguousName
+}
+
+// NameNotFoundError is returned when no object has the given name. Suggestions
+// holds the objects with similar names, closest first.
+// 未找到名称, 并给出相近的候选
+type NameNotFoundError struct {
+	Kind        string // state, member, label, cycle or module
+	Name        string
+	Suggestions []NameCandidate
+}
+
+// Error implements the error interface
+func (e *NameNotFoundError) Error() string {
+	msg := fmt.Sprintf("未找到名称为 '%s' 的%s", e.Name, kindNames[e.Kind])
+	if len(e.Suggestions) > 0 {
+		msg += fmt.Sprintf(" (是否要找 %s?)", joinCandidates(e.Suggestions))
+	}
+	return msg + ": " + client.ErrNotFound.Error()
+}
+
+// Unwrap returns client.ErrNotFound, so that client.IsNotFound reports true
+func (e *NameNotFoundError) Unwrap() error {
+	return client.ErrNotFound
+}
+
+func joinCandidates(candidates []NameCandidate) string {
+	parts := make([]string, len(candidates))
+	for i, c := range candidates {
+		parts[i] = c.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// nameComparisons are the ways names are compared, in order of preference
+var nameComparisons = []func(a, b string) bool{
+	func(a, b string) bool { return a == b },
+	func(a, b string) bool { return normalizeName(a) == normalizeName(b) },
+}
+
+// normalizeName lowercases a name and collapses its whitespace
+func normalizeName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// findByName returns the ID of the item with a name equal to name, comparing
+// exactly first and then ignoring case and whitespace. It returns an
+// AmbiguousNameError if several items match and a NameNotFoundError with
+// suggestions if none does.
+func findByName[T any](items []T, kind string, name string, names func(item *T) []string, candidate func(item *T) NameCandidate) (string, error) {
+	for _, equal := range nameComparisons {
+		var matches []NameCandidate
+		for i := range items {
+			for _, n := range names(&items[i]) {
+				if n != "" && equal(n, name) {
+					matches = append(matches, candidate(&items[i]))
+					break
+				}
+			}
+		}
+		switch {
+		case len(matches) == 1:
+			return matches[0].ID, nil
+		case len(matches) > 1:
+			return "", &AmbiguousNameError{Kind: kind, Name: name, Candidates: matches}
+		}
+	}
+	return "", &NameNotFoundError{Kind: kind, Name: name, Suggestions: suggestNames(items, name, names, candidate)}
+}
+
+// suggestNames returns the items with a name close to name by edit distance,
+// or starting with it, such as the email of a member starting with "alice"
+func suggestNames[T any](items []T, name string, names func(item *T) []string, candidate func(item *T) NameCandidate) []NameCandidate {
+	query := normalizeName(name)
+	if query == "" {
+		return nil
+	}
+	limit := utf8.RuneCountInString(query)/4 + 1
+
+	type scored struct {
+		candidate NameCandidate
+		distance  int
+	}
+	var found []scored
+	for i := range items {
+		best := -1
+		for _, n := range names(&items[i]) {
+			n = normalizeName(n)
+			if n == "" {
+				continue
+			}
+			d := editDistance(query, n)
+			if strings.HasPrefix(n, query) && d > limit {
+				d = limit
+			}
+			if d <= limit && (best < 0 || d < best) {
+				best = d
+			}
+		}
+		if best >= 0 {
+			found = append(found, scored{candidate(&items[i]), best})
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		if found[i].distance != found[j].distance {
+			return found[i].distance < found[j].distance
+		}
+		return found[i].candidate.Name < found[j].candidate.Name
+	})
+
+	if len(found) > maxSuggestions {
+		found = found[:maxSuggestions]
+	}
+	suggestions := make([]NameCandidate, len(found))
+	for i := range found {
+		suggestions[i] = found[i].candidate
+	}
+	return suggestions
+}
+
+// editDistance returns the number of insertions, deletions, substitutions and
+// transpositions of adjacent characters turning a into b
+func editDistance(a string, b string) int {
+	s, t := []rune(a), []rune(b)
+	// d[i][j] is the distance between s[:i] and t[:j]
+	d := make([][]int, len(s)+1)
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			d[i][j] = minInt(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				d[i][j] = minInt(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(s)][len(t)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/GeekWorkCode/plane-api-go/client"
+	"github.com/GeekWorkCode/plane-api-go/models"
+	"github.com/GeekWorkCode/plane-api-go/planetest"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestEditDistance tests the edit distance used for suggestions
+// 测试编辑距离
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("alice", "alice"))
+	assert.Equal(t, 1, editDistance("alcie", "alice"))
+	assert.Equal(t, 1, editDistance("alic", "alice"))
+	assert.Equal(t, 2, editDistance("bobby", "bob"))
+	assert.Equal(t, 1, editDistance("进行中", "进行"))
+	assert.Equal(t, 3, editDistance("", "abc"))
+}
+
+// TestFindByName tests ambiguity errors and suggestions of name lookups
+// 测试名称歧义与建议
+func TestFindByName(t *testing.T) {
+	members := []models.Member{
+		{Member: models.MemberUser{ID: "u1", DisplayName: "alice", FirstName: "Alice", LastName: "Smith", Email: "alice@example.com"}},
+		{Member: models.MemberUser{ID: "u2", DisplayName: "bob", Email: "bob@example.com"}},
+		{Member: models.MemberUser{ID: "u3", DisplayName: "bob", Email: "robert@example.com"}},
+		{Member: models.MemberUser{ID: "u4", DisplayName: "Carol  Jones", Email: "cjones@example.com"}},
+	}
+	names := func(m *models.Member) []string {
+		return []string{m.Member.DisplayName, m.Member.FirstName + " " + m.Member.LastName, m.Member.Email}
+	}
+	candidate := func(m *models.Member) NameCandidate {
+		return NameCandidate{ID: m.Member.ID, Name: m.Member.DisplayName, Detail: m.Member.Email}
+	}
+
+	t.Run("Match", func(t *testing.T) {
+		id, err := findByName(members, kindMembers, "carol jones", names, candidate)
+		assert.NoError(t, err)
+		assert.Equal(t, "u4", id)
+	})
+
+	t.Run("Ambiguous", func(t *testing.T) {
+		_, err := findByName(members, kindMembers, "bob", names, candidate)
+		var ambiguous *AmbiguousNameError
+		if !assert.True(t, errors.As(err, &ambiguous)) {
+			return
+		}
+		assert.True(t, errors.Is(err, ErrAmbiguousName))
+		assert.Equal(t, "bob", ambiguous.Name)
+		assert.Equal(t, []NameCandidate{
+			{ID: "u2", Name: "bob", Detail: "bob@example.com"},
+			{ID: "u3", Name: "bob", Detail: "robert@example.com"},
+		}, ambiguous.Candidates)
+		assert.Contains(t, err.Error(), "robert@example.com")
+
+		// 邮箱可以消除歧义
+		id, err := findByName(members, kindMembers, "robert@example.com", names, candidate)
+		assert.NoError(t, err)
+		assert.Equal(t, "u3", id)
+	})
+
+	t.Run("Suggestions", func(t *testing.T) {
+		_, err := findByName(members, kindMembers, "alcie", names, candidate)
+		var notFound *NameNotFoundError
+		if !assert.True(t, errors.As(err, &notFound)) {
+			return
+		}
+		assert.True(t, errors.Is(err, client.ErrNotFound))
+		assert.Equal(t, "alcie", notFound.Name)
+		assert.Equal(t, []NameCandidate{{ID: "u1", Name: "alice", Detail: "alice@example.com"}}, notFound.Suggestions)
+		assert.Contains(t, err.Error(), "是否要找 'alice' (alice@example.com)")
+	})
+
+	t.Run("EmailPrefix", func(t *testing.T) {
+		_, err := findByName(members, kindMembers, "rob", names, candidate)
+		var notFound *NameNotFoundError
+		if !assert.True(t, errors.As(err, &notFound)) {
+			return
+		}
+		ids := []string{}
+		for _, s := range notFound.Suggestions {
+			ids = append(ids, s.ID)
+		}
+		assert.Contains(t, ids, "u3")
+	})
+
+	t.Run("NoSuggestions", func(t *testing.T) {
+		_, err := findByName(members, kindMembers, "zzzzzzzz", names, candidate)
+		var notFound *NameNotFoundError
+		if !assert.True(t, errors.As(err, &notFound)) {
+			return
+		}
+		assert.Empty(t, notFound.Suggestions)
+	})
+}
+
+// TestIssuesServiceAmbiguousAssignee tests that an ambiguous assignee name fails the create
+// 测试重名成员导致创建失败并列出候选
+func TestIssuesServiceAmbiguousAssignee(t *testing.T) {
+	srv := planetest.NewServer()
+	defer srv.Close()
+	fx := srv.Seed()
+	other := srv.AddMember(fx.ProjectID, models.MemberUser{DisplayName: "bob", Email: "bob@other.example.com"})
+
+	c := client.NewClient(srv.APIKey)
+	c.SetBaseURL(srv.BaseURL())
+	issues := NewIssuesService(c)
+
+	_, err := issues.Create(fx.WorkspaceSlug, fx.ProjectID, &IssueCreateRequest{Name: "Who?", AssigneeNames: []string{"bob"}})
+	var ambiguous *AmbiguousNameError
+	if !assert.True(t, errors.As(err, &ambiguous)) {
+		return
+	}
+	assert.Len(t, ambiguous.Candidates, 2)
+	assert.Equal(t, other.ID, ambiguous.Candidates[1].ID)
+
+	_, err = issues.Create(fx.WorkspaceSlug, fx.ProjectID, &IssueCreateRequest{Name: "Typo", StateName: "In Progres"})
+	var notFound *NameNotFoundError
+	if !assert.True(t, errors.As(err, &notFound)) {
+		return
+	}
+	if assert.NotEmpty(t, notFound.Suggestions) {
+		assert.Equal(t, "In Progress", notFound.Suggestions[0].Name)
+	}
+}
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/GeekWorkCode/plane-api-go/client"
+	"github.com/GeekWorkCode/plane-api-go/models"
+)
+
+// ListOptions specifies the cursor pagination parameters of list endpoints
+// 分页参数
+type ListOptions struct {
+	// PerPage is the number of results per page. Zero uses the server default.
+	PerPage int
+	// Cursor is the cursor of the page to fetch, as returned in NextCursor.
+	Cursor string
+}
+
+// values encodes the options as query parameters
+func (o *ListOptions) values() url.Values {
+	v := url.Values{}
+	if o == nil {
+		return v
+	}
+	if o.PerPage > 0 {
+		v.Set("per_page", strconv.Itoa(o.PerPage))
+	}
+	if o.Cursor != "" {
+		v.Set("cursor", o.Cursor)
+	}
+	return v
+}
+
+// withQuery appends the encoded query parameters to path
+func withQuery(path string, v url.Values) string {
+	if len(v) == 0 {
+		return path
+	}
+	if strings.Contains(path, "?") {
+		return path + "&" + v.Encode()
+	}
+	return path + "?" + v.Encode()
+}
+
+// listPage fetches a single page of a paginated endpoint
+func listPage[T any](ctx context.Context, c *client.Client, path string, query url.Values) (*models.PaginatedResponse[T], error) {
+	req, err := c.NewRequestWithContext(ctx, http.MethodGet, withQuery(path, query), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(models.PaginatedResponse[T])
+	_, err = c.Do(req, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// listAll fetches every page of a paginated endpoint by following next_cursor
+func listAll[T any](ctx context.Context, c *client.Client, path string, query url.Values) ([]T, error) {
+	it := newListIterator[T](ctx, c, path, query)
+
+	results := []T{}
+	for it.Next() {
+		results = append(results, it.Item())
+	}
+	return results, it.Err()
+}
+
+// withCursor returns a copy of query with the cursor parameter set
+func withCursor(query url.Values, cursor string) url.Values {
+	v := url.Values{}
+	for key, values := range query {
+		v[key] = append([]string(nil), values...)
+	}
+	if cursor != "" {
+		v.Set("cursor", cursor)
+	}
+	return v
+}
+
+// Iterator walks over the results of a paginated endpoint, fetching
+// further pages on demand.
+//
+//	it := client.Issues.Iterate(ctx, "workspace", "project-id", nil)
+//	for it.Next() {
+//		issue := it.Item()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type Iterator[T any] struct {
+	ctx    context.Context
+	fetch  func(ctx context.Context, cursor string) (*models.PaginatedResponse[T], error)
+	page   *models.PaginatedResponse[T]
+	items  []T
+	index  int
+	cursor string
+	done   bool
+	err    error
+}
+
+func newIterator[T any](ctx context.Context, fetch func(ctx context.Context, cursor string) (*models.PaginatedResponse[T], error)) *Iterator[T] {
+	return &Iterator[T]{ctx: ctx, fetch: fetch, index: -1}
+}
+
+// newListIterator creates an iterator over a paginated endpoint, starting
+// at the cursor given in query, if any
+func newListIterator[T any](ctx context.Context, c *client.Client, path string, query url.Values) *Iterator[T] {
+	it := newIterator(ctx, func(ctx context.Context, cursor string) (*models.PaginatedResponse[T], error) {
+		return listPage[T](ctx, c, path, withCursor(query, cursor))
+	})
+	it.cursor = query.Get("cursor")
+	return it
+}
+
+// Next advances to the next result, fetching the next page when needed.
+// It returns false when the results are exhausted or an error occurred.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	for it.index+1 >= len(it.items) {
+		if it.done {
+			return false
+		}
+		page, err := it.fetch(it.ctx, it.cursor)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.page = page
+		it.items = page.Results
+		it.index = -1
+		if page.HasNextPage() && page.NextCursor != it.cursor {
+			it.cursor = page.NextCursor
+		} else {
+			it.done = true
+		}
+	}
+	it.index++
+	return true
+}
+
+// Item returns the current result
+func (it *Iterator[T]) Item() T {
+	return it.items[it.index]
+}
+
+// Page returns the page the current result belongs to
+func (it *Iterator[T]) Page() *models.PaginatedResponse[T] {
+	return it.page
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GeekWorkCode/plane-api-go/client"
+	"github.com/GeekWorkCode/plane-api-go/models"
+	"github.com/GeekWorkCode/plane-api-go/planetest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newPagedIssuesServer serves three pages of issues, two issues per page
+func newPagedIssuesServer(t *testing.T) *httptest.Server {
+	pages := map[string]models.IssuesResponse{
+		"": {
+			Results:         []models.Issue{{ID: "1"}, {ID: "2"}},
+			NextCursor:      "2:1:0",
+			NextPageResults: true,
+		},
+		"2:1:0": {
+			Results:         []models.Issue{{ID: "3"}, {ID: "4"}},
+			NextCursor:      "2:2:0",
+			NextPageResults: true,
+		},
+		"2:2:0": {
+			Results:    []models.Issue{{ID: "5"}},
+			NextCursor: "2:3:0",
+		},
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/workspaces/ws/projects/p1/issues/", r.URL.Path)
+		page, ok := pages[r.URL.Query().Get("cursor")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+}
+
+// TestPagination tests cursor pagination of list endpoints
+// 测试列表接口的游标分页
+func TestPagination(t *testing.T) {
+	server := newPagedIssuesServer(t)
+	defer server.Close()
+
+	c := client.NewClient("test-key")
+	c.SetBaseURL(server.URL)
+	s := NewIssuesService(c)
+
+	t.Run("List", func(t *testing.T) {
+		issues, err := s.List("ws", "p1")
+		assert.NoError(t, err)
+		assert.Len(t, issues, 5)
+		assert.Equal(t, "5", issues[4].ID)
+	})
+
+	t.Run("ListPage", func(t *testing.T) {
+		page, err := s.ListPage("ws", "p1", &IssueListOptions{ListOptions: ListOptions{PerPage: 2, Cursor: "2:1:0"}})
+		assert.NoError(t, err)
+		assert.Len(t, page.Results, 2)
+		assert.Equal(t, "3", page.Results[0].ID)
+		assert.True(t, page.HasNextPage())
+		assert.Equal(t, "2:2:0", page.NextCursor)
+	})
+
+	t.Run("Iterate", func(t *testing.T) {
+		it := s.Iterate(context.Background(), "ws", "p1", &IssueListOptions{ListOptions: ListOptions{Cursor: "2:1:0"}})
+		var ids []string
+		for it.Next() {
+			ids = append(ids, it.Item().ID)
+		}
+		assert.NoError(t, it.Err())
+		assert.Equal(t, []string{"3", "4", "5"}, ids)
+	})
+
+	t.Run("IterateError", func(t *testing.T) {
+		it := s.Iterate(context.Background(), "ws", "p1", &IssueListOptions{ListOptions: ListOptions{Cursor: "missing"}})
+		assert.False(t, it.Next())
+		assert.Error(t, it.Err())
+	})
+}
+
+// TestPaginationModulesAndCycles tests paging through modules and the issues of cycles and modules
+// 测试模块列表以及周期和模块中问题的分页
+func TestPaginationModulesAndCycles(t *testing.T) {
+	srv := planetest.NewServer()
+	defer srv.Close()
+	srv.PerPage = 1
+	fx := srv.Seed()
+	ws, proj := fx.WorkspaceSlug, fx.ProjectID
+	second := srv.AddIssue(ws, proj, models.Issue{Name: "Second"})
+	issueIDs := []string{fx.IssueID, second.ID}
+	srv.AddModule(ws, proj, models.Module{Name: "Backend"})
+	module := srv.AddModule(ws, proj, models.Module{Name: "Frontend"})
+	cycle := srv.AddCycle(ws, proj, models.Cycle{Name: "Sprint 1"})
+
+	c := client.NewClient(srv.APIKey)
+	c.SetBaseURL(srv.BaseURL())
+	modules := NewModulesService(c)
+	cycles := NewCyclesService(c)
+	assert.NoError(t, modules.AddIssues(ws, proj, module.ID, issueIDs))
+	assert.NoError(t, cycles.AddIssues(ws, proj, cycle.ID, issueIDs))
+
+	t.Run("Modules", func(t *testing.T) {
+		all, err := modules.List(ws, proj)
+		if assert.NoError(t, err) && assert.Len(t, all, 2) {
+			assert.Equal(t, module.ID, all[1].ID)
+		}
+
+		page, err := modules.ListPage(ws, proj, nil)
+		if assert.NoError(t, err) {
+			assert.Len(t, page.Results, 1)
+			assert.True(t, page.HasNextPage())
+		}
+	})
+
+	t.Run("ModuleIssues", func(t *testing.T) {
+		issues, err := modules.ListIssues(ws, proj, module.ID)
+		if assert.NoError(t, err) && assert.Len(t, issues, 2) {
+			assert.Equal(t, second.ID, issues[1].ID)
+		}
+
+		var ids []string
+		it := modules.IterateIssues(context.Background(), ws, proj, module.ID, nil)
+		for it.Next() {
+			ids = append(ids, it.Item().ID)
+		}
+		assert.NoError(t, it.Err())
+		assert.Equal(t, issueIDs, ids)
+	})
+
+	t.Run("CycleIssues", func(t *testing.T) {
+		issues, err := cycles.ListIssues(ws, proj, cycle.ID)
+		if assert.NoError(t, err) && assert.Len(t, issues, 2) {
+			assert.Equal(t, second.ID, issues[1].ID)
+		}
+
+		page, err := cycles.ListIssuesPage(ws, proj, cycle.ID, &ListOptions{PerPage: 2})
+		if assert.NoError(t, err) {
+			assert.Len(t, page.Results, 2)
+			assert.False(t, page.HasNextPage())
+		}
+	})
+}
@@ -1,7 +1,7 @@
 package api
 
 import (
-	"fmt"
+	"context"
 	"net/http"
 
 	"github.com/GeekWorkCode/plane-api-go/client"
@@ -35,25 +35,43 @@ type ProjectUpdateRequest struct {
 
 // List returns all projects in a workspace
 func (s *ProjectsService) List(workspaceSlug string) ([]models.Project, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/", workspaceSlug)
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
-	if err != nil {
-		return nil, err
-	}
+	return s.ListWithContext(context.Background(), workspaceSlug)
+}
 
-	response := new(models.ProjectsResponse)
-	_, err = s.client.Do(req, response)
-	if err != nil {
-		return nil, err
-	}
+// ListWithContext returns all projects in a workspace, following pagination until every page is fetched
+func (s *ProjectsService) ListWithContext(ctx context.Context, workspaceSlug string) ([]models.Project, error) {
+	path := client.Pathf("/workspaces/%s/projects/", workspaceSlug)
+	results, err := listAll[models.Project](ctx, s.client, path, nil)
+	return results, err
+}
+
+// ListPage returns a single page of projects in a workspace
+func (s *ProjectsService) ListPage(workspaceSlug string, opts *ListOptions) (*models.PaginatedResponse[models.Project], error) {
+	return s.ListPageWithContext(context.Background(), workspaceSlug, opts)
+}
 
-	return response.Results, nil
+// ListPageWithContext returns a single page of projects in a workspace
+func (s *ProjectsService) ListPageWithContext(ctx context.Context, workspaceSlug string, opts *ListOptions) (*models.PaginatedResponse[models.Project], error) {
+	path := client.Pathf("/workspaces/%s/projects/", workspaceSlug)
+	page, err := listPage[models.Project](ctx, s.client, path, opts.values())
+	return page, err
+}
+
+// Iterate returns an iterator over all projects in a workspace, starting at opts.Cursor
+func (s *ProjectsService) Iterate(ctx context.Context, workspaceSlug string, opts *ListOptions) *Iterator[models.Project] {
+	path := client.Pathf("/workspaces/%s/projects/", workspaceSlug)
+	return newListIterator[models.Project](ctx, s.client, path, opts.values())
 }
 
 // Get returns a project by its ID
 func (s *ProjectsService) Get(workspaceSlug string, projectID string) (*models.Project, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/", workspaceSlug, projectID)
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	return s.GetWithContext(context.Background(), workspaceSlug, projectID)
+}
+
+// GetWithContext returns a project by its ID
+func (s *ProjectsService) GetWithContext(ctx context.Context, workspaceSlug string, projectID string) (*models.Project, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/", workspaceSlug, projectID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -65,8 +83,13 @@ func (s *ProjectsService) Get(workspaceSlug string, projectID string) (*models.P
 
 // Create creates a new project
 func (s *ProjectsService) Create(workspaceSlug string, createRequest *ProjectCreateRequest) (*models.Project, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/", workspaceSlug)
-	req, err := s.client.NewRequest(http.MethodPost, path, createRequest)
+	return s.CreateWithContext(context.Background(), workspaceSlug, createRequest)
+}
+
+// CreateWithContext creates a new project
+func (s *ProjectsService) CreateWithContext(ctx context.Context, workspaceSlug string, createRequest *ProjectCreateRequest) (*models.Project, error) {
+	path := client.Pathf("/workspaces/%s/projects/", workspaceSlug)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, path, createRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -78,8 +101,13 @@ func (s *ProjectsService) Create(workspaceSlug string, createRequest *ProjectCre
 
 // Update updates a project
 func (s *ProjectsService) Update(workspaceSlug string, projectID string, updateRequest *ProjectUpdateRequest) (*models.Project, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/", workspaceSlug, projectID)
-	req, err := s.client.NewRequest(http.MethodPatch, path, updateRequest)
+	return s.UpdateWithContext(context.Background(), workspaceSlug, projectID, updateRequest)
+}
+
+// UpdateWithContext updates a project
+func (s *ProjectsService) UpdateWithContext(ctx context.Context, workspaceSlug string, projectID string, updateRequest *ProjectUpdateRequest) (*models.Project, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/", workspaceSlug, projectID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPatch, path, updateRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -91,8 +119,13 @@ func (s *ProjectsService) Update(workspaceSlug string, projectID string, updateR
 
 // Delete deletes a project
 func (s *ProjectsService) Delete(workspaceSlug string, projectID string) error {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/", workspaceSlug, projectID)
-	req, err := s.client.NewRequest(http.MethodDelete, path, nil)
+	return s.DeleteWithContext(context.Background(), workspaceSlug, projectID)
+}
+
+// DeleteWithContext deletes a project
+func (s *ProjectsService) DeleteWithContext(ctx context.Context, workspaceSlug string, projectID string) error {
+	path := client.Pathf("/workspaces/%s/projects/%s/", workspaceSlug, projectID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodDelete, path, nil)
 	if err != nil {
 		return err
 	}
@@ -1,31 +1,26 @@
 package api
 
 import (
-	"os"
 	"testing"
 
-	"github.com/GeekWorkCode/plane-api-go/client"
 	"github.com/stretchr/testify/assert"
 )
 
 // TestProjectsService tests all methods of the ProjectsService
 // 测试 ProjectsService 的所有方法
 func TestProjectsService(t *testing.T) {
-	// Get API key from environment
-	apiKey := os.Getenv("PLANE_API_KEY")
-	if apiKey == "" {
-		t.Skip("PLANE_API_KEY not set")
-	}
+	// Use the live API if configured, the fake server otherwise
+	env := newTestEnv(t)
 
-	// Get required IDs from environment
-	workspaceSlug := os.Getenv("PLANE_WORKSPACE_SLUG")
+	// Get required IDs from the test environment
+	workspaceSlug := env.workspaceSlug
 
 	if workspaceSlug == "" {
 		t.Skip("Required environment variables not set")
 	}
 
 	// Create a new client
-	client := client.NewClient(apiKey)
+	client := env.client
 	s := NewProjectsService(client)
 
 	var projectID string
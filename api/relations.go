@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/GeekWorkCode/plane-api-go/client"
+	"github.com/GeekWorkCode/plane-api-go/models"
+)
+
+// IssueRelationsService handles communication with the issue relation related endpoints
+type IssueRelationsService struct {
+	client *client.Client
+}
+
+// NewIssueRelationsService creates a new issue relations service
+func NewIssueRelationsService(client *client.Client) *IssueRelationsService {
+	return &IssueRelationsService{
+		client: client,
+	}
+}
+
+// relationCreateRequest represents the request body for creating relations
+type relationCreateRequest struct {
+	RelationType string   `json:"relation_type"`
+	Issues       []string `json:"issues"`
+}
+
+// relationDeleteRequest represents the request body for removing a relation
+type relationDeleteRequest struct {
+	RelationType string `json:"relation_type"`
+	RelatedIssue string `json:"related_issue"`
+}
+
+// validRelationType 检查关系类型是否有效
+func validRelationType(relationType string) error {
+	switch relationType {
+	case models.RelationBlocking, models.RelationBlockedBy, models.RelationDuplicate, models.RelationRelatesTo:
+		return nil
+	}
+	return fmt.Errorf("无效的关系类型: '%s'", relationType)
+}
+
+// List returns the related issues of an issue, grouped by relation type
+func (s *IssueRelationsService) List(workspaceSlug string, projectID string, issueID string) (*models.IssueRelations, error) {
+	return s.ListWithContext(context.Background(), workspaceSlug, projectID, issueID)
+}
+
+// ListWithContext returns the related issues of an issue, grouped by relation type
+func (s *IssueRelationsService) ListWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string) (*models.IssueRelations, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/issue-relation/", workspaceSlug, projectID, issueID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	relations := new(models.IssueRelations)
+	_, err = s.client.Do(req, relations)
+	return relations, err
+}
+
+// Create relates an issue to other issues, e.g. with models.RelationBlocking
+// the issue blocks each of relatedIssueIDs
+func (s *IssueRelationsService) Create(workspaceSlug string, projectID string, issueID string, relationType string, relatedIssueIDs []string) ([]models.IssueRelation, error) {
+	return s.CreateWithContext(context.Background(), workspaceSlug, projectID, issueID, relationType, relatedIssueIDs)
+}
+
+// CreateWithContext relates an issue to other issues
+func (s *IssueRelationsService) CreateWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, relationType string, relatedIssueIDs []string) ([]models.IssueRelation, error) {
+	if err := validRelationType(relationType); err != nil {
+		return nil, err
+	}
+
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/issue-relation/", workspaceSlug, projectID, issueID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, path, &relationCreateRequest{
+		RelationType: relationType,
+		Issues:       relatedIssueIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var relations []models.IssueRelation
+	_, err = s.client.Do(req, &relations)
+	return relations, err
+}
+
+// Delete removes a relation between two issues
+func (s *IssueRelationsService) Delete(workspaceSlug string, projectID string, issueID string, relationType string, relatedIssueID string) error {
+	return s.DeleteWithContext(context.Background(), workspaceSlug, projectID, issueID, relationType, relatedIssueID)
+}
+
+// DeleteWithContext removes a relation between two issues
+func (s *IssueRelationsService) DeleteWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, relationType string, relatedIssueID string) error {
+	if err := validRelationType(relationType); err != nil {
+		return err
+	}
+
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/remove-relation/", workspaceSlug, projectID, issueID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, path, &relationDeleteRequest{
+		RelationType: relationType,
+		RelatedIssue: relatedIssueID,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(req, nil)
+	return err
+}
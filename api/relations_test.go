@@ -0,0 +1,77 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/GeekWorkCode/plane-api-go/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIssueRelationsService tests all methods of the IssueRelationsService
+// 测试 IssueRelationsService 的所有方法
+func TestIssueRelationsService(t *testing.T) {
+	// Use the live API if configured, the fake server otherwise
+	env := newTestEnv(t)
+
+	workspaceSlug := env.workspaceSlug
+	projectID := env.projectID
+
+	if workspaceSlug == "" || projectID == "" {
+		t.Skip("Required environment variables not set")
+	}
+
+	s := NewIssueRelationsService(env.client)
+	issuesService := NewIssuesService(env.client)
+
+	// 创建两个测试问题
+	blocker, err := issuesService.Create(workspaceSlug, projectID, &IssueCreateRequest{Name: "Test Blocker"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer issuesService.Delete(workspaceSlug, projectID, blocker.ID)
+	blocked, err := issuesService.Create(workspaceSlug, projectID, &IssueCreateRequest{Name: "Test Blocked"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer issuesService.Delete(workspaceSlug, projectID, blocked.ID)
+
+	// Test Create method
+	// 测试 Create 方法
+	t.Run("Create", func(t *testing.T) {
+		relations, err := s.Create(workspaceSlug, projectID, blocker.ID, models.RelationBlocking, []string{blocked.ID})
+		assert.NoError(t, err)
+		assert.Len(t, relations, 1)
+	})
+
+	// Test List method
+	// 测试 List 方法
+	t.Run("List", func(t *testing.T) {
+		relations, err := s.List(workspaceSlug, projectID, blocker.ID)
+		if assert.NoError(t, err) && assert.Len(t, relations.Blocking, 1) {
+			assert.Equal(t, blocked.ID, relations.Blocking[0].ID)
+		}
+
+		relations, err = s.List(workspaceSlug, projectID, blocked.ID)
+		if assert.NoError(t, err) && assert.Len(t, relations.Of(models.RelationBlockedBy), 1) {
+			assert.Equal(t, blocker.ID, relations.BlockedBy[0].ID)
+		}
+	})
+
+	// Test Delete method
+	// 测试 Delete 方法
+	t.Run("Delete", func(t *testing.T) {
+		err := s.Delete(workspaceSlug, projectID, blocker.ID, models.RelationBlocking, blocked.ID)
+		assert.NoError(t, err)
+
+		relations, err := s.List(workspaceSlug, projectID, blocked.ID)
+		assert.NoError(t, err)
+		assert.Empty(t, relations.BlockedBy)
+	})
+
+	// Test an invalid relation type
+	// 测试无效的关系类型
+	t.Run("InvalidRelationType", func(t *testing.T) {
+		_, err := s.Create(workspaceSlug, projectID, blocker.ID, "blocks", []string{blocked.ID})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,259 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GeekWorkCode/plane-api-go/client"
+	"github.com/GeekWorkCode/plane-api-go/models"
+)
+
+// DefaultResolverTTL is how long a Resolver keeps the lists it fetched
+const DefaultResolverTTL = 5 * time.Minute
+
+// Kinds of project objects cached by a Resolver
+const (
+	kindStates  = "state"
+	kindMembers = "member"
+	kindLabels  = "label"
+	kindCycles  = "cycle"
+	kindModules = "module"
+)
+
+// kindNames are the names of the kinds used in error messages
+var kindNames = map[string]string{
+	kindStates:  "状态",
+	kindMembers: "成员",
+	kindLabels:  "标签",
+	kindCycles:  "周期",
+	kindModules: "模块",
+}
+
+// Resolver resolves names of the states, members, labels, cycles and modules of
+// a project to IDs. The lists it fetches are cached per project for the TTL, and
+// a name that is not found in a cached list is looked up again in a fresh one.
+// Services sharing a Resolver invalidate it when they change the cached objects.
+// 名称解析器, 按项目缓存状态、成员、标签、周期和模块列表
+type Resolver struct {
+	client *client.Client
+
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[resolverKey]resolverEntry
+}
+
+type resolverKey struct {
+	kind          string
+	workspaceSlug string
+	projectID     string
+}
+
+type resolverEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// NewResolver creates a resolver caching lists for ttl. A ttl of zero disables caching.
+func NewResolver(client *client.Client, ttl time.Duration) *Resolver {
+	return &Resolver{
+		client:  client,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[resolverKey]resolverEntry{},
+	}
+}
+
+// SetTTL sets how long lists are cached. A ttl of zero disables caching.
+func (r *Resolver) SetTTL(ttl time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ttl = ttl
+}
+
+// Invalidate drops the cached lists of a project
+func (r *Resolver) Invalidate(workspaceSlug string, projectID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key := range r.entries {
+		if key.workspaceSlug == workspaceSlug && key.projectID == projectID {
+			delete(r.entries, key)
+		}
+	}
+}
+
+// InvalidateAll drops all cached lists
+func (r *Resolver) InvalidateAll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = map[resolverKey]resolverEntry{}
+}
+
+// invalidate drops one cached list of a project
+func (r *Resolver) invalidate(kind string, workspaceSlug string, projectID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.entries, resolverKey{kind, workspaceSlug, projectID})
+}
+
+// cachedList returns the cached list of a kind, fetching it if it is missing or expired.
+// fresh reports whether the list was fetched by this call.
+func cachedList[T any](ctx context.Context, r *Resolver, kind string, workspaceSlug string, projectID string, fetch func(ctx context.Context) ([]T, error)) (items []T, fresh bool, err error) {
+	key := resolverKey{kind, workspaceSlug, projectID}
+	r.mu.Lock()
+	entry, ok := r.entries[key]
+	now := r.now()
+	r.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.value.([]T), false, nil
+	}
+
+	items, err = fetch(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("获取%s列表失败: %w", kindNames[kind], err)
+	}
+	r.mu.Lock()
+	if r.ttl > 0 {
+		r.entries[key] = resolverEntry{value: items, expires: now.Add(r.ttl)}
+	}
+	r.mu.Unlock()
+	return items, true, nil
+}
+
+// list returns a copy of the cached list of a kind
+func list[T any](ctx context.Context, r *Resolver, kind string, workspaceSlug string, projectID string, fetch func(ctx context.Context) ([]T, error)) ([]T, error) {
+	items, _, err := cachedList(ctx, r, kind, workspaceSlug, projectID, fetch)
+	if err != nil {
+		return nil, err
+	}
+	return append([]T(nil), items...), nil
+}
+
+// resolve finds an ID in the cached list of a kind. If find does not find it in
+// a cached list, the list is fetched again and searched once more.
+func resolve[T any](ctx context.Context, r *Resolver, kind string, workspaceSlug string, projectID string, fetch func(ctx context.Context) ([]T, error), find func(items []T) (string, error)) (string, error) {
+	items, fresh, err := cachedList(ctx, r, kind, workspaceSlug, projectID, fetch)
+	if err != nil {
+		return "", err
+	}
+	id, err := find(items)
+	if err == nil || fresh || !errors.Is(err, client.ErrNotFound) {
+		return id, err
+	}
+
+	r.invalidate(kind, workspaceSlug, projectID)
+	items, _, err = cachedList(ctx, r, kind, workspaceSlug, projectID, fetch)
+	if err != nil {
+		return "", err
+	}
+	return find(items)
+}
+
+func (r *Resolver) fetchStates(workspaceSlug string, projectID string) func(context.Context) ([]models.State, error) {
+	return func(ctx context.Context) ([]models.State, error) {
+		return NewStatesService(r.client).ListWithContext(ctx, workspaceSlug, projectID)
+	}
+}
+
+func (r *Resolver) fetchMembers(workspaceSlug string, projectID string) func(context.Context) ([]models.Member, error) {
+	return func(ctx context.Context) ([]models.Member, error) {
+		return NewMembersService(r.client).ListWithContext(ctx, workspaceSlug, projectID)
+	}
+}
+
+func (r *Resolver) fetchLabels(workspaceSlug string, projectID string) func(context.Context) ([]models.Label, error) {
+	return func(ctx context.Context) ([]models.Label, error) {
+		return NewLabelsService(r.client).ListWithContext(ctx, workspaceSlug, projectID)
+	}
+}
+
+func (r *Resolver) fetchCycles(workspaceSlug string, projectID string) func(context.Context) ([]models.Cycle, error) {
+	return func(ctx context.Context) ([]models.Cycle, error) {
+		return NewCyclesService(r.client).ListWithContext(ctx, workspaceSlug, projectID)
+	}
+}
+
+func (r *Resolver) fetchModules(workspaceSlug string, projectID string) func(context.Context) ([]models.Module, error) {
+	return func(ctx context.Context) ([]models.Module, error) {
+		return NewModulesService(r.client).ListWithContext(ctx, workspaceSlug, projectID)
+	}
+}
+
+// States returns the states of a project
+func (r *Resolver) States(ctx context.Context, workspaceSlug string, projectID string) ([]models.State, error) {
+	return list(ctx, r, kindStates, workspaceSlug, projectID, r.fetchStates(workspaceSlug, projectID))
+}
+
+// Members returns the members of a project
+func (r *Resolver) Members(ctx context.Context, workspaceSlug string, projectID string) ([]models.Member, error) {
+	return list(ctx, r, kindMembers, workspaceSlug, projectID, r.fetchMembers(workspaceSlug, projectID))
+}
+
+// Labels returns the labels of a project
+func (r *Resolver) Labels(ctx context.Context, workspaceSlug string, projectID string) ([]models.Label, error) {
+	return list(ctx, r, kindLabels, workspaceSlug, projectID, r.fetchLabels(workspaceSlug, projectID))
+}
+
+// Cycles returns the cycles of a project
+func (r *Resolver) Cycles(ctx context.Context, workspaceSlug string, projectID string) ([]models.Cycle, error) {
+	return list(ctx, r, kindCycles, workspaceSlug, projectID, r.fetchCycles(workspaceSlug, projectID))
+}
+
+// Modules returns the modules of a project
+func (r *Resolver) Modules(ctx context.Context, workspaceSlug string, projectID string) ([]models.Module, error) {
+	return list(ctx, r, kindModules, workspaceSlug, projectID, r.fetchModules(workspaceSlug, projectID))
+}
+
+// StateID returns the ID of the state with the given name. If no state matches,
+// the returned error is a *NameNotFoundError with the states of similar names.
+func (r *Resolver) StateID(ctx context.Context, workspaceSlug string, projectID string, name string) (string, error) {
+	return resolve(ctx, r, kindStates, workspaceSlug, projectID, r.fetchStates(workspaceSlug, projectID), func(states []models.State) (string, error) {
+		return findByName(states, kindStates, name,
+			func(s *models.State) []string { return []string{s.Name} },
+			func(s *models.State) NameCandidate { return NameCandidate{ID: s.ID, Name: s.Name} })
+	})
+}
+
+// MemberID returns the user ID of the member with the given display name, full name or email.
+// If several members match, the returned error is an *AmbiguousNameError listing them.
+func (r *Resolver) MemberID(ctx context.Context, workspaceSlug string, projectID string, name string) (string, error) {
+	return resolve(ctx, r, kindMembers, workspaceSlug, projectID, r.fetchMembers(workspaceSlug, projectID), func(members []models.Member) (string, error) {
+		return findByName(members, kindMembers, name,
+			func(m *models.Member) []string {
+				fullName := strings.TrimSpace(m.Member.FirstName + " " + m.Member.LastName)
+				return []string{m.Member.DisplayName, fullName, m.Member.Email}
+			},
+			func(m *models.Member) NameCandidate {
+				return NameCandidate{ID: m.Member.ID, Name: m.Member.DisplayName, Detail: m.Member.Email}
+			})
+	})
+}
+
+// LabelID returns the ID of the label with the given ID, name or path such as "area/backend"
+func (r *Resolver) LabelID(ctx context.Context, workspaceSlug string, projectID string, ref string) (string, error) {
+	return resolve(ctx, r, kindLabels, workspaceSlug, projectID, r.fetchLabels(workspaceSlug, projectID), func(labels []models.Label) (string, error) {
+		return resolveLabel(labels, ref)
+	})
+}
+
+// CycleID returns the ID of the cycle with the given name
+func (r *Resolver) CycleID(ctx context.Context, workspaceSlug string, projectID string, name string) (string, error) {
+	return resolve(ctx, r, kindCycles, workspaceSlug, projectID, r.fetchCycles(workspaceSlug, projectID), func(cycles []models.Cycle) (string, error) {
+		return findByName(cycles, kindCycles, name,
+			func(c *models.Cycle) []string { return []string{c.Name} },
+			func(c *models.Cycle) NameCandidate { return NameCandidate{ID: c.ID, Name: c.Name} })
+	})
+}
+
+// ModuleID returns the ID of the module with the given name
+func (r *Resolver) ModuleID(ctx context.Context, workspaceSlug string, projectID string, name string) (string, error) {
+	return resolve(ctx, r, kindModules, workspaceSlug, projectID, r.fetchModules(workspaceSlug, projectID), func(modules []models.Module) (string, error) {
+		return findByName(modules, kindModules, name,
+			func(m *models.Module) []string { return []string{m.Name} },
+			func(m *models.Module) NameCandidate { return NameCandidate{ID: m.ID, Name: m.Name} })
+	})
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GeekWorkCode/plane-api-go/client"
+	"github.com/GeekWorkCode/plane-api-go/planetest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newResolverTestClient returns a client for a seeded fake server and the number
+// of GET requests sent per path suffix such as "states/"
+func newResolverTestClient(t *testing.T) (*client.Client, planetest.Fixture, map[string]int) {
+	srv := planetest.NewServer()
+	t.Cleanup(srv.Close)
+	fx := srv.Seed()
+
+	c := client.NewClient(srv.APIKey)
+	c.SetBaseURL(srv.BaseURL())
+	gets := map[string]int{}
+	c.Use(client.BeforeRequest(func(req *http.Request) error {
+		if req.Method == http.MethodGet {
+			parts := strings.Split(strings.TrimSuffix(req.URL.Path, "/"), "/")
+			gets[parts[len(parts)-1]+"/"]++
+		}
+		return nil
+	}))
+	return c, fx, gets
+}
+
+// TestResolver tests name lookups and caching of the resolver
+// 测试名称解析与缓存
+func TestResolver(t *testing.T) {
+	c, fx, gets := newResolverTestClient(t)
+	ctx := context.Background()
+	r := NewResolver(c, time.Minute)
+	now := time.Now()
+	r.now = func() time.Time { return now }
+
+	t.Run("StateID", func(t *testing.T) {
+		id, err := r.StateID(ctx, fx.WorkspaceSlug, fx.ProjectID, "In Progress")
+		assert.NoError(t, err)
+		assert.NotEmpty(t, id)
+
+		// 忽略大小写
+		same, err := r.StateID(ctx, fx.WorkspaceSlug, fx.ProjectID, "in progress")
+		assert.NoError(t, err)
+		assert.Equal(t, id, same)
+		assert.Equal(t, 1, gets["states/"])
+	})
+
+	t.Run("MemberID", func(t *testing.T) {
+		alice := fx.Members[0].ID
+		for _, name := range []string{"alice", "Alice Smith", "alice@example.com", "ALICE"} {
+			id, err := r.MemberID(ctx, fx.WorkspaceSlug, fx.ProjectID, name)
+			assert.NoError(t, err, name)
+			assert.Equal(t, alice, id, name)
+		}
+		assert.Equal(t, 1, gets["members/"])
+	})
+
+	t.Run("NotFoundRefetches", func(t *testing.T) {
+		_, err := r.StateID(ctx, fx.WorkspaceSlug, fx.ProjectID, "Missing")
+		assert.True(t, errors.Is(err, client.ErrNotFound))
+		assert.Equal(t, 2, gets["states/"])
+	})
+
+	t.Run("Expiry", func(t *testing.T) {
+		before := gets["states/"]
+		_, err := r.States(ctx, fx.WorkspaceSlug, fx.ProjectID)
+		assert.NoError(t, err)
+		assert.Equal(t, before, gets["states/"])
+
+		now = now.Add(2 * time.Minute)
+		_, err = r.States(ctx, fx.WorkspaceSlug, fx.ProjectID)
+		assert.NoError(t, err)
+		assert.Equal(t, before+1, gets["states/"])
+	})
+
+	t.Run("Invalidate", func(t *testing.T) {
+		before := gets["members/"]
+		r.Invalidate(fx.WorkspaceSlug, fx.ProjectID)
+		_, err := r.Members(ctx, fx.WorkspaceSlug, fx.ProjectID)
+		assert.NoError(t, err)
+		assert.Equal(t, before+1, gets["members/"])
+	})
+
+	t.Run("NoCache", func(t *testing.T) {
+		r := NewResolver(c, 0)
+		before := gets["labels/"]
+		for i := 0; i < 2; i++ {
+			_, err := r.Labels(ctx, fx.WorkspaceSlug, fx.ProjectID)
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, before+2, gets["labels/"])
+	})
+}
+
+// TestResolverSharedByServices tests that services sharing a resolver fetch lists
+// once and see the changes made through each other
+// 测试多个服务共享解析器时的缓存与失效
+func TestResolverSharedByServices(t *testing.T) {
+	c, fx, gets := newResolverTestClient(t)
+	ws, proj := fx.WorkspaceSlug, fx.ProjectID
+
+	resolver := NewResolver(c, DefaultResolverTTL)
+	issues := NewIssuesService(c)
+	issues.SetResolver(resolver)
+	labels := NewLabelsService(c)
+	labels.SetResolver(resolver)
+	comments := NewCommentsService(c)
+	comments.SetResolver(resolver)
+
+	for i := 0; i < 3; i++ {
+		_, err := issues.Create(ws, proj, &IssueCreateRequest{
+			Name:          "Cached lookups",
+			StateName:     "Todo",
+			AssigneeNames: []string{"bob"},
+		})
+		assert.NoError(t, err)
+	}
+	_, err := comments.Create(ws, proj, fx.IssueID, &CommentRequest{CommentHTML: "<p>hi</p>", DisplayName: "bob"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, gets["states/"])
+	assert.Equal(t, 1, gets["members/"])
+
+	// 创建标签会使缓存的标签列表失效
+	_, err = issues.Create(ws, proj, &IssueCreateRequest{Name: "No labels yet"})
+	assert.NoError(t, err)
+	_, err = resolver.Labels(context.Background(), ws, proj)
+	assert.NoError(t, err)
+	label, err := labels.Create(ws, proj, &LabelCreateRequest{Name: "backend", Color: "#00ff00"})
+	assert.NoError(t, err)
+	issue, err := issues.Create(ws, proj, &IssueCreateRequest{Name: "Labelled", LabelNames: []string{"Backend"}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{label.ID}, issue.Labels)
+	assert.Equal(t, 2, gets["labels/"])
+
+	_, err = labels.Update(ws, proj, label.ID, &LabelUpdateRequest{Name: "server"})
+	assert.NoError(t, err)
+	_, err = issues.AddLabels(ws, proj, issue.ID, "server")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, gets["labels/"])
+}
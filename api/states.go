@@ -1,7 +1,7 @@
 package api
 
 import (
-	"fmt"
+	"context"
 	"net/http"
 
 	"github.com/GeekWorkCode/plane-api-go/client"
@@ -10,16 +10,23 @@ import (
 
 // StatesService handles communication with the state related endpoints
 type StatesService struct {
-	client *client.Client
+	client   *client.Client
+	resolver *Resolver
 }
 
 // NewStatesService creates a new states service
 func NewStatesService(client *client.Client) *StatesService {
 	return &StatesService{
-		client: client,
+		client:   client,
+		resolver: NewResolver(client, DefaultResolverTTL),
 	}
 }
 
+// SetResolver makes the service share a name resolver with other services
+func (s *StatesService) SetResolver(resolver *Resolver) {
+	s.resolver = resolver
+}
+
 // StateCreateRequest represents the request body for creating a state
 type StateCreateRequest struct {
 	Name        string `json:"name"`
@@ -36,25 +43,43 @@ type StateUpdateRequest struct {
 
 // List returns all states in a project
 func (s *StatesService) List(workspaceSlug string, projectID string) ([]models.State, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/states/", workspaceSlug, projectID)
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
-	if err != nil {
-		return nil, err
-	}
+	return s.ListWithContext(context.Background(), workspaceSlug, projectID)
+}
 
-	response := new(models.StatesResponse)
-	_, err = s.client.Do(req, response)
-	if err != nil {
-		return nil, err
-	}
+// ListWithContext returns all states in a project, following pagination until every page is fetched
+func (s *StatesService) ListWithContext(ctx context.Context, workspaceSlug string, projectID string) ([]models.State, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/states/", workspaceSlug, projectID)
+	results, err := listAll[models.State](ctx, s.client, path, nil)
+	return results, err
+}
+
+// ListPage returns a single page of states in a project
+func (s *StatesService) ListPage(workspaceSlug string, projectID string, opts *ListOptions) (*models.PaginatedResponse[models.State], error) {
+	return s.ListPageWithContext(context.Background(), workspaceSlug, projectID, opts)
+}
+
+// ListPageWithContext returns a single page of states in a project
+func (s *StatesService) ListPageWithContext(ctx context.Context, workspaceSlug string, projectID string, opts *ListOptions) (*models.PaginatedResponse[models.State], error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/states/", workspaceSlug, projectID)
+	page, err := listPage[models.State](ctx, s.client, path, opts.values())
+	return page, err
+}
 
-	return response.Results, nil
+// Iterate returns an iterator over all states in a project, starting at opts.Cursor
+func (s *StatesService) Iterate(ctx context.Context, workspaceSlug string, projectID string, opts *ListOptions) *Iterator[models.State] {
+	path := client.Pathf("/workspaces/%s/projects/%s/states/", workspaceSlug, projectID)
+	return newListIterator[models.State](ctx, s.client, path, opts.values())
 }
 
 // Get returns a state by its ID
 func (s *StatesService) Get(workspaceSlug string, projectID string, stateID string) (*models.State, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/states/%s/", workspaceSlug, projectID, stateID)
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	return s.GetWithContext(context.Background(), workspaceSlug, projectID, stateID)
+}
+
+// GetWithContext returns a state by its ID
+func (s *StatesService) GetWithContext(ctx context.Context, workspaceSlug string, projectID string, stateID string) (*models.State, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/states/%s/", workspaceSlug, projectID, stateID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -66,38 +91,56 @@ func (s *StatesService) Get(workspaceSlug string, projectID string, stateID stri
 
 // Create creates a new state
 func (s *StatesService) Create(workspaceSlug string, projectID string, createRequest *StateCreateRequest) (*models.State, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/states/", workspaceSlug, projectID)
-	req, err := s.client.NewRequest(http.MethodPost, path, createRequest)
+	return s.CreateWithContext(context.Background(), workspaceSlug, projectID, createRequest)
+}
+
+// CreateWithContext creates a new state
+func (s *StatesService) CreateWithContext(ctx context.Context, workspaceSlug string, projectID string, createRequest *StateCreateRequest) (*models.State, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/states/", workspaceSlug, projectID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, path, createRequest)
 	if err != nil {
 		return nil, err
 	}
 
 	state := new(models.State)
 	_, err = s.client.Do(req, state)
+	s.resolver.invalidate(kindStates, workspaceSlug, projectID)
 	return state, err
 }
 
 // Update updates a state
 func (s *StatesService) Update(workspaceSlug string, projectID string, stateID string, updateRequest *StateUpdateRequest) (*models.State, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/states/%s/", workspaceSlug, projectID, stateID)
-	req, err := s.client.NewRequest(http.MethodPatch, path, updateRequest)
+	return s.UpdateWithContext(context.Background(), workspaceSlug, projectID, stateID, updateRequest)
+}
+
+// UpdateWithContext updates a state
+func (s *StatesService) UpdateWithContext(ctx context.Context, workspaceSlug string, projectID string, stateID string, updateRequest *StateUpdateRequest) (*models.State, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/states/%s/", workspaceSlug, projectID, stateID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPatch, path, updateRequest)
 	if err != nil {
 		return nil, err
 	}
 
 	state := new(models.State)
 	_, err = s.client.Do(req, state)
+	s.resolver.invalidate(kindStates, workspaceSlug, projectID)
 	return state, err
 }
 
 // Delete deletes a state
 func (s *StatesService) Delete(workspaceSlug string, projectID string, stateID string) error {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/states/%s/", workspaceSlug, projectID, stateID)
-	req, err := s.client.NewRequest(http.MethodDelete, path, nil)
+	return s.DeleteWithContext(context.Background(), workspaceSlug, projectID, stateID)
+}
+
+// DeleteWithContext deletes a state
+func (s *StatesService) DeleteWithContext(ctx context.Context, workspaceSlug string, projectID string, stateID string) error {
+	path := client.Pathf("/workspaces/%s/projects/%s/states/%s/", workspaceSlug, projectID, stateID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodDelete, path, nil)
 	if err != nil {
 		return err
 	}
 
 	_, err = s.client.Do(req, nil)
+	s.resolver.invalidate(kindStates, workspaceSlug, projectID)
 	return err
 }
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"os"
+	"testing"
+
+	"github.com/GeekWorkCode/plane-api-go/client"
+	"github.com/GeekWorkCode/plane-api-go/planetest"
+)
+
+// testEnv holds the client and identifiers the service tests run against
+type testEnv struct {
+	client        *client.Client
+	workspaceSlug string
+	projectID     string
+	issueID       string
+}
+
+// newTestEnv returns the live API configured by the PLANE_* environment
+// variables when PLANE_API_KEY is set, and a seeded in-memory fake server otherwise
+// 设置了 PLANE_API_KEY 时使用真实 API，否则使用内存中的模拟服务器
+func newTestEnv(t *testing.T) *testEnv {
+	if apiKey := os.Getenv("PLANE_API_KEY"); apiKey != "" {
+		c := client.NewClient(apiKey)
+		if baseURL := os.Getenv("PLANE_API_BASE_URL"); baseURL != "" {
+			c.SetBaseURL(baseURL)
+		}
+		return &testEnv{
+			client:        c,
+			workspaceSlug: os.Getenv("PLANE_WORKSPACE_SLUG"),
+			projectID:     os.Getenv("PLANE_PROJECT_ID"),
+			issueID:       os.Getenv("PLANE_ISSUE_ID"),
+		}
+	}
+
+	server := planetest.NewServer()
+	t.Cleanup(server.Close)
+	fixture := server.Seed()
+
+	c := client.NewClient(server.APIKey)
+	c.SetBaseURL(server.BaseURL())
+	return &testEnv{
+		client:        c,
+		workspaceSlug: fixture.WorkspaceSlug,
+		projectID:     fixture.ProjectID,
+		issueID:       fixture.IssueID,
+	}
+}
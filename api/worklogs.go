@@ -1,7 +1,7 @@
 package api
 
 import (
-	"fmt"
+	"context"
 	"net/http"
 
 	"github.com/GeekWorkCode/plane-api-go/client"
@@ -34,8 +34,13 @@ type WorklogUpdateRequest struct {
 
 // List returns all worklogs for an issue
 func (s *WorklogsService) List(workspaceSlug string, projectID string, issueID string) ([]models.Worklog, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/issues/%s/worklogs/", workspaceSlug, projectID, issueID)
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	return s.ListWithContext(context.Background(), workspaceSlug, projectID, issueID)
+}
+
+// ListWithContext returns all worklogs for an issue
+func (s *WorklogsService) ListWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string) ([]models.Worklog, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/worklogs/", workspaceSlug, projectID, issueID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -47,8 +52,13 @@ func (s *WorklogsService) List(workspaceSlug string, projectID string, issueID s
 
 // Create creates a new worklog for an issue
 func (s *WorklogsService) Create(workspaceSlug string, projectID string, issueID string, createRequest *WorklogCreateRequest) (*models.Worklog, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/issues/%s/worklogs/", workspaceSlug, projectID, issueID)
-	req, err := s.client.NewRequest(http.MethodPost, path, createRequest)
+	return s.CreateWithContext(context.Background(), workspaceSlug, projectID, issueID, createRequest)
+}
+
+// CreateWithContext creates a new worklog for an issue
+func (s *WorklogsService) CreateWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, createRequest *WorklogCreateRequest) (*models.Worklog, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/worklogs/", workspaceSlug, projectID, issueID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, path, createRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -60,8 +70,13 @@ func (s *WorklogsService) Create(workspaceSlug string, projectID string, issueID
 
 // Update updates a worklog
 func (s *WorklogsService) Update(workspaceSlug string, projectID string, issueID string, worklogID string, updateRequest *WorklogUpdateRequest) (*models.Worklog, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/issues/%s/worklogs/%s/", workspaceSlug, projectID, issueID, worklogID)
-	req, err := s.client.NewRequest(http.MethodPatch, path, updateRequest)
+	return s.UpdateWithContext(context.Background(), workspaceSlug, projectID, issueID, worklogID, updateRequest)
+}
+
+// UpdateWithContext updates a worklog
+func (s *WorklogsService) UpdateWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, worklogID string, updateRequest *WorklogUpdateRequest) (*models.Worklog, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/worklogs/%s/", workspaceSlug, projectID, issueID, worklogID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPatch, path, updateRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -73,8 +88,13 @@ func (s *WorklogsService) Update(workspaceSlug string, projectID string, issueID
 
 // Delete deletes a worklog
 func (s *WorklogsService) Delete(workspaceSlug string, projectID string, issueID string, worklogID string) error {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/issues/%s/worklogs/%s/", workspaceSlug, projectID, issueID, worklogID)
-	req, err := s.client.NewRequest(http.MethodDelete, path, nil)
+	return s.DeleteWithContext(context.Background(), workspaceSlug, projectID, issueID, worklogID)
+}
+
+// DeleteWithContext deletes a worklog
+func (s *WorklogsService) DeleteWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, worklogID string) error {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/worklogs/%s/", workspaceSlug, projectID, issueID, worklogID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodDelete, path, nil)
 	if err != nil {
 		return err
 	}
@@ -85,8 +105,13 @@ func (s *WorklogsService) Delete(workspaceSlug string, projectID string, issueID
 
 // GetTotalTime returns the total time spent for all issues in a project
 func (s *WorklogsService) GetTotalTime(workspaceSlug string, projectID string) ([]models.WorklogTotal, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/total-worklogs/", workspaceSlug, projectID)
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	return s.GetTotalTimeWithContext(context.Background(), workspaceSlug, projectID)
+}
+
+// GetTotalTimeWithContext returns the total time spent for all issues in a project
+func (s *WorklogsService) GetTotalTimeWithContext(ctx context.Context, workspaceSlug string, projectID string) ([]models.WorklogTotal, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/total-worklogs/", workspaceSlug, projectID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -98,8 +123,13 @@ func (s *WorklogsService) GetTotalTime(workspaceSlug string, projectID string) (
 
 // Get returns a single worklog by ID
 func (s *WorklogsService) Get(workspaceSlug string, projectID string, issueID string, worklogID string) (*models.Worklog, error) {
-	path := fmt.Sprintf("/workspaces/%s/projects/%s/issues/%s/worklogs/%s/", workspaceSlug, projectID, issueID, worklogID)
-	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	return s.GetWithContext(context.Background(), workspaceSlug, projectID, issueID, worklogID)
+}
+
+// GetWithContext returns a single worklog by ID
+func (s *WorklogsService) GetWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, worklogID string) (*models.Worklog, error) {
+	path := client.Pathf("/workspaces/%s/projects/%s/issues/%s/worklogs/%s/", workspaceSlug, projectID, issueID, worklogID)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1,10 +1,8 @@
 package api
 
 import (
-	"os"
 	"testing"
 
-	"github.com/GeekWorkCode/plane-api-go/client"
 	"github.com/GeekWorkCode/plane-api-go/models"
 	"github.com/stretchr/testify/assert"
 )
@@ -14,18 +12,15 @@ import (
 func TestWorklogsService(t *testing.T) {
 	// Initialize test client
 	// 初始化测试客户端
-	apiKey := os.Getenv("PLANE_API_KEY")
-	if apiKey == "" {
-		t.Skip("PLANE_API_KEY not set")
-	}
-	c := client.NewClient(apiKey)
+	env := newTestEnv(t)
+	c := env.client
 	s := NewWorklogsService(c)
 
 	// Test data
 	// 测试数据
-	workspaceSlug := os.Getenv("PLANE_WORKSPACE_SLUG")
-	projectID := os.Getenv("PLANE_PROJECT_ID")
-	issueID := os.Getenv("PLANE_ISSUE_ID")
+	workspaceSlug := env.workspaceSlug
+	projectID := env.projectID
+	issueID := env.issueID
 
 	if workspaceSlug == "" || projectID == "" || issueID == "" {
 		t.Skip("Required environment variables not set")
@@ -116,7 +111,7 @@ func TestWorklogsService(t *testing.T) {
 			}
 		}
 		assert.NotNil(t, issueTotal)
-		assert.Equal(t, createReq.Duration, issueTotal.Duration)
+		assert.EqualValues(t, createReq.Duration, issueTotal.Duration)
 
 		// Clean up
 		// 清理
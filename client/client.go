@@ -2,18 +2,17 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"fmt"
+	"errors"
 	"io"
 	"net/http"
-	"net/http/httputil"
 	"strings"
-
-	"github.com/GeekWorkCode/plane-api-go/models"
+	"time"
 )
 
 const (
-	defaultBaseURL = "https://app.plane.so/api/v1"
+	defaultBaseURL = "https://api.plane.so/api/v1"
 	userAgent      = "plane-api-go/0.1.0"
 )
 
@@ -24,6 +23,19 @@ type Client struct {
 	baseURL    string
 	userAgent  string
 	debug      bool
+
+	// transport and timeout set with SetTransport and SetTimeout, kept when
+	// the HTTP client is replaced
+	transport  http.RoundTripper
+	timeout    time.Duration
+	hasTimeout bool
+
+	retryPolicy *RetryPolicy
+	limiter     *RateLimiter
+	logger      Logger
+
+	logBodyLimit int
+	middlewares  []Middleware
 }
 
 // NewClient creates a new Plane API client
@@ -31,7 +43,7 @@ func NewClient(apiKey string) *Client {
 	return &Client{
 		apiKey:     apiKey,
 		httpClient: &http.Client{},
-		baseURL:    "https://api.plane.so/api/v1",
+		baseURL:    defaultBaseURL,
 		userAgent:  userAgent,
 		debug:      false,
 	}
@@ -42,14 +54,80 @@ func (c *Client) SetDebug(debug bool) {
 	c.debug = debug
 }
 
-// SetBaseURL sets the base URL for API requests
+// SetBaseURL sets the base URL for API requests, including any path
+// prefix such as /api/v1. An empty URL restores the default.
 func (c *Client) SetBaseURL(baseURL string) {
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
 	c.baseURL = strings.TrimRight(baseURL, "/")
 }
 
+// SetHTTPClient sets the HTTP client used to send requests. A transport or
+// timeout set with SetTransport or SetTimeout is applied to a copy of it, so
+// the order of these calls does not matter.
+func (c *Client) SetHTTPClient(httpClient *http.Client) {
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	if c.transport != nil || c.hasTimeout {
+		copied := *httpClient
+		if c.transport != nil {
+			copied.Transport = c.transport
+		}
+		if c.hasTimeout {
+			copied.Timeout = c.timeout
+		}
+		httpClient = &copied
+	}
+	c.httpClient = httpClient
+}
+
+// HTTPClient returns the HTTP client used to send requests
+func (c *Client) HTTPClient() *http.Client {
+	return c.httpClient
+}
+
+// SetTransport sets the transport of the HTTP client, e.g. to use a proxy
+// or mTLS. The HTTP client is copied, so a client passed to SetHTTPClient
+// is left untouched.
+func (c *Client) SetTransport(transport http.RoundTripper) {
+	c.transport = transport
+	httpClient := *c.httpClient
+	httpClient.Transport = transport
+	c.httpClient = &httpClient
+}
+
+// SetTimeout sets the timeout of the HTTP client for a single request attempt.
+// The HTTP client is copied, so a client passed to SetHTTPClient is left untouched.
+func (c *Client) SetTimeout(timeout time.Duration) {
+	c.timeout, c.hasTimeout = timeout, true
+	httpClient := *c.httpClient
+	httpClient.Timeout = timeout
+	c.httpClient = &httpClient
+}
+
+// SetUserAgentSuffix appends suffix to the default User-Agent header
+func (c *Client) SetUserAgentSuffix(suffix string) {
+	c.userAgent = userAgent
+	if suffix != "" {
+		c.userAgent += " " + suffix
+	}
+}
+
 // NewRequest creates a new API request
 func (c *Client) NewRequest(method, path string, body interface{}) (*http.Request, error) {
-	url := c.baseURL + path
+	return c.NewRequestWithContext(context.Background(), method, path, body)
+}
+
+// NewRequestWithContext creates a new API request bound to ctx.
+// Cancelling ctx aborts the request once it is passed to Do.
+// The path is joined to the base URL with BuildURL.
+func (c *Client) NewRequestWithContext(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
+	url, err := c.BuildURL(path, nil)
+	if err != nil {
+		return nil, err
+	}
 
 	var buf io.ReadWriter
 	if body != nil {
@@ -60,7 +138,7 @@ func (c *Client) NewRequest(method, path string, body interface{}) (*http.Reques
 		}
 	}
 
-	req, err := http.NewRequest(method, url, buf)
+	req, err := http.NewRequestWithContext(ctx, method, url, buf)
 	if err != nil {
 		return nil, err
 	}
@@ -77,49 +155,22 @@ func (c *Client) NewRequest(method, path string, body interface{}) (*http.Reques
 
 // Do sends an API request and returns the API response
 func (c *Client) Do(req *http.Request, v interface{}) (*http.Response, error) {
-	if c.debug {
-		dump, err := httputil.DumpRequestOut(req, true)
-		if err == nil {
-			fmt.Printf("REQUEST:\n%s\n", string(dump))
-		}
-	}
-
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.handler()(req)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if c.debug {
-		dump, err := httputil.DumpResponse(resp, true)
-		if err == nil {
-			fmt.Printf("RESPONSE:\n%s\n", string(dump))
-		}
+	if resp == nil {
+		return nil, errors.New("plane: middleware returned neither a response nor an error")
+	}
+	// 中间件可能直接返回没有 Body 的响应
+	if resp.Body == nil {
+		resp.Body = http.NoBody
 	}
+	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return resp, fmt.Errorf("API error: %s (Status: %d)", req.URL.String(), resp.StatusCode)
-		}
-
-		if c.debug {
-			fmt.Printf("ERROR RESPONSE BODY: %s\n", string(bodyBytes))
-		}
-
-		var errorResp models.ErrorResponse
-		if err := json.Unmarshal(bodyBytes, &errorResp); err != nil {
-			return resp, fmt.Errorf("API error: %s (Status: %d)\nBody: %s", req.URL.String(), resp.StatusCode, string(bodyBytes))
-		}
-
-		if errorResp.Error != "" {
-			return resp, fmt.Errorf("API error: %s (Status: %d)\nError: %s", req.URL.String(), resp.StatusCode, errorResp.Error)
-		}
-		if errorResp.Message != "" {
-			return resp, fmt.Errorf("API error: %s (Status: %d)\nMessage: %s", req.URL.String(), resp.StatusCode, errorResp.Message)
-		}
-
-		return resp, fmt.Errorf("API error: %s (Status: %d)\nBody: %s", req.URL.String(), resp.StatusCode, string(bodyBytes))
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return resp, newAPIError(req, resp, bodyBytes)
 	}
 
 	if v != nil {
@@ -135,3 +186,29 @@ func (c *Client) Do(req *http.Request, v interface{}) (*http.Response, error) {
 
 	return resp, nil
 }
+
+// do sends req with the HTTP client. The API key is not forwarded when a redirect
+// leads to another host, such as the storage URL an attachment download redirects to.
+// In debug mode the request is dumped here, after the middlewares changed it.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	httpClient := *c.httpClient
+	checkRedirect := httpClient.CheckRedirect
+	httpClient.CheckRedirect = func(next *http.Request, via []*http.Request) error {
+		if next.URL.Host != via[0].URL.Host {
+			next.Header.Del("X-API-Key")
+		}
+		if checkRedirect != nil {
+			return checkRedirect(next, via)
+		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+	c.logRequestDump(req)
+	resp, err := httpClient.Do(req)
+	if err == nil {
+		c.logResponseDump(req, resp)
+	}
+	return resp, err
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewRequestWithContext verifies that a cancelled context aborts Do
+// 测试取消的上下文会中断请求
+func TestNewRequestWithContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient("test-key")
+	c.SetBaseURL(server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, err := c.NewRequestWithContext(ctx, http.MethodGet, "/workspaces/test/projects/", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, ctx, req.Context())
+
+	_, err = c.Do(req, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+// TestDoRedirectDropsAPIKey verifies that the API key is not sent to another host on redirect
+// 测试重定向到其他主机时不会发送 API 密钥
+func TestDoRedirectDropsAPIKey(t *testing.T) {
+	var storageKey string
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		storageKey = r.Header.Get("X-API-Key")
+		w.Write([]byte("file content"))
+	}))
+	defer storage.Close()
+
+	var apiKeys []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKeys = append(apiKeys, r.Header.Get("X-API-Key"))
+		if r.URL.Path == "/moved/" {
+			http.Redirect(w, r, "/download/", http.StatusFound)
+			return
+		}
+		http.Redirect(w, r, storage.URL+"/file", http.StatusFound)
+	}))
+	defer api.Close()
+
+	c := NewClient("test-key")
+	c.SetBaseURL(api.URL)
+
+	req, err := c.NewRequest(http.MethodGet, "/moved/", nil)
+	assert.NoError(t, err)
+	var body bytes.Buffer
+	_, err = c.Do(req, &body)
+	assert.NoError(t, err)
+	assert.Equal(t, "file content", body.String())
+	assert.Equal(t, []string{"test-key", "test-key"}, apiKeys)
+	assert.Empty(t, storageKey)
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/GeekWorkCode/plane-api-go/models"
+)
+
+// ErrNotFound is returned when a resource could not be found without the
+// API answering with a 404, e.g. when a lookup by name has no match.
+// IsNotFound reports true for it as well as for 404 responses.
+var ErrNotFound = errors.New("not found")
+
+// APIError is returned by Do when the API answers with a non-2xx status code
+type APIError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	RequestID  string
+
+	// Response is the decoded error body, nil if the body was not JSON
+	Response *models.ErrorResponse
+
+	// Body is the raw response body
+	Body []byte
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("API error: %s %s (Status: %d)", e.Method, e.URL, e.StatusCode)
+	switch {
+	case e.Response != nil && e.Response.Error != "":
+		msg += "\nError: " + e.Response.Error
+	case e.Response != nil && e.Response.Message != "":
+		msg += "\nMessage: " + e.Response.Message
+	case len(e.Body) > 0:
+		msg += "\nBody: " + string(e.Body)
+	}
+	if e.RequestID != "" {
+		msg += "\nRequest ID: " + e.RequestID
+	}
+	return msg
+}
+
+// newAPIError builds an APIError from a failed response and its body
+func newAPIError(req *http.Request, resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		RequestID:  resp.Header.Get("X-Request-ID"),
+		Body:       body,
+	}
+
+	var errorResp models.ErrorResponse
+	if len(body) > 0 && json.Unmarshal(body, &errorResp) == nil {
+		apiErr.Response = &errorResp
+	}
+	return apiErr
+}
+
+// StatusCode returns the HTTP status code of the API error wrapped in err, or 0
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsNotFound reports whether err is a 404 API error or wraps ErrNotFound
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound || errors.Is(err, ErrNotFound)
+}
+
+// IsUnauthorized reports whether err is a 401 API error, e.g. an invalid API key
+func IsUnauthorized(err error) bool {
+	return StatusCode(err) == http.StatusUnauthorized
+}
+
+// IsForbidden reports whether err is a 403 API error
+func IsForbidden(err error) bool {
+	return StatusCode(err) == http.StatusForbidden
+}
+
+// IsBadRequest reports whether err is a 400 API error, e.g. a validation failure
+func IsBadRequest(err error) bool {
+	return StatusCode(err) == http.StatusBadRequest
+}
+
+// IsRateLimited reports whether err is a 429 API error
+func IsRateLimited(err error) bool {
+	return StatusCode(err) == http.StatusTooManyRequests
+}
+
+// IsServerError reports whether err is a 5xx API error
+func IsServerError(err error) bool {
+	return StatusCode(err) >= http.StatusInternalServerError
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAPIError tests that failed responses are returned as *APIError
+// 测试失败的响应以 *APIError 返回
+func TestAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-ID", "req-123")
+		switch r.URL.Path {
+		case "/missing/":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"Issue not found"}`))
+		case "/limited/":
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"message":"Request was throttled"}`))
+		case "/unauthorized/":
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`Unauthorized`))
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient("test-key")
+	c.SetBaseURL(server.URL)
+
+	do := func(path string) error {
+		req, err := c.NewRequest(http.MethodGet, path, nil)
+		assert.NoError(t, err)
+		_, err = c.Do(req, nil)
+		return err
+	}
+
+	t.Run("NotFound", func(t *testing.T) {
+		err := do("/missing/")
+		var apiErr *APIError
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		assert.Equal(t, http.MethodGet, apiErr.Method)
+		assert.Equal(t, "req-123", apiErr.RequestID)
+		assert.Equal(t, "Issue not found", apiErr.Response.Error)
+		assert.True(t, IsNotFound(err))
+		assert.False(t, IsRateLimited(err))
+		assert.Contains(t, err.Error(), "Issue not found")
+	})
+
+	t.Run("RateLimited", func(t *testing.T) {
+		err := do("/limited/")
+		assert.True(t, IsRateLimited(err))
+		assert.Contains(t, err.Error(), "Request was throttled")
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		err := do("/unauthorized/")
+		var apiErr *APIError
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Nil(t, apiErr.Response)
+		assert.Equal(t, "Unauthorized", string(apiErr.Body))
+		assert.True(t, IsUnauthorized(err))
+	})
+
+	t.Run("Wrapped", func(t *testing.T) {
+		err := fmt.Errorf("获取评论列表失败: %w", do("/missing/"))
+		assert.True(t, IsNotFound(err))
+		assert.Equal(t, http.StatusNotFound, StatusCode(err))
+	})
+
+	t.Run("ErrNotFound", func(t *testing.T) {
+		err := fmt.Errorf("成员未找到: %s: %w", "m1", ErrNotFound)
+		assert.True(t, IsNotFound(err))
+		assert.Equal(t, 0, StatusCode(err))
+	})
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Logger receives the client's log output as a message and alternating
+// key/value pairs. Its method set matches *slog.Logger, so a *slog.Logger
+// can be passed directly.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// SetLogger sets the logger receiving the client's log output.
+// A nil logger restores the default, which prints warnings and errors to
+// stderr, and debug output to stdout only when debug mode is enabled.
+func (c *Client) SetLogger(logger Logger) {
+	c.logger = logger
+}
+
+// SetLogBodyLimit truncates request and response dumps logged in debug mode
+// to limit bytes. Zero disables truncation.
+func (c *Client) SetLogBodyLimit(limit int) {
+	c.logBodyLimit = limit
+}
+
+// Logger returns the logger used by the client and its services
+func (c *Client) Logger() Logger {
+	if c.logger != nil {
+		return c.logger
+	}
+	return &defaultLogger{debug: c.debug, out: os.Stdout, errOut: os.Stderr}
+}
+
+// defaultLogger prints debug output to stdout and warnings and errors to stderr
+type defaultLogger struct {
+	debug  bool
+	out    io.Writer
+	errOut io.Writer
+}
+
+func (l *defaultLogger) Debug(msg string, args ...any) {
+	if l.debug {
+		l.print(l.out, "DEBUG", msg, args)
+	}
+}
+
+func (l *defaultLogger) Info(msg string, args ...any) {
+	if l.debug {
+		l.print(l.out, "INFO", msg, args)
+	}
+}
+
+func (l *defaultLogger) Warn(msg string, args ...any)  { l.print(l.errOut, "WARN", msg, args) }
+func (l *defaultLogger) Error(msg string, args ...any) { l.print(l.errOut, "ERROR", msg, args) }
+
+func (l *defaultLogger) print(w io.Writer, level string, msg string, args []any) {
+	var b strings.Builder
+	b.WriteString(level)
+	b.WriteString(" ")
+	b.WriteString(msg)
+	for i := 0; i+1 < len(args); i += 2 {
+		fmt.Fprintf(&b, " %v=%v", args[i], args[i+1])
+	}
+	fmt.Fprintln(w, b.String())
+}
+
+// secretHeaderPattern matches header lines that carry credentials in a dump
+var secretHeaderPattern = regexp.MustCompile(`(?im)^((?:x-api-key|authorization|cookie|set-cookie):[ \t]*)[^\r\n]*`)
+
+// redact removes credentials from a request or response dump
+func redact(dump []byte) string {
+	return secretHeaderPattern.ReplaceAllString(string(dump), "${1}[REDACTED]")
+}
+
+// truncate shortens s to limit bytes if limit is positive
+func truncate(s string, limit int) string {
+	if limit <= 0 || len(s) <= limit {
+		return s
+	}
+	return fmt.Sprintf("%s... (%d bytes truncated)", s[:limit], len(s)-limit)
+}
+
+// logRequestDump logs the full request in debug mode
+func (c *Client) logRequestDump(req *http.Request) {
+	if !c.debug {
+		return
+	}
+	dump, err := httputil.DumpRequestOut(req, true)
+	if err != nil {
+		return
+	}
+	c.Logger().Debug("plane api request dump",
+		"method", req.Method,
+		"path", req.URL.Path,
+		"dump", truncate(redact(dump), c.logBodyLimit))
+}
+
+// logResponseDump logs the full response in debug mode
+func (c *Client) logResponseDump(req *http.Request, resp *http.Response) {
+	if !c.debug {
+		return
+	}
+	dump, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		return
+	}
+	c.Logger().Debug("plane api response dump",
+		"method", req.Method,
+		"path", req.URL.Path,
+		"status", resp.StatusCode,
+		"dump", truncate(redact(dump), c.logBodyLimit))
+}
+
+// logAttempt logs the outcome of a single request attempt at debug level.
+// Failures are returned to the caller, so they are not logged as errors.
+func (c *Client) logAttempt(req *http.Request, resp *http.Response, err error, latency time.Duration, attempt int) {
+	args := []any{
+		"method", req.Method,
+		"path", req.URL.Path,
+		"latency", latency,
+		"attempt", attempt,
+	}
+	if err != nil {
+		args = append(args, "error", err)
+	}
+	if resp != nil {
+		args = append(args, "status", resp.StatusCode)
+		if id := resp.Header.Get("X-Request-ID"); id != "" {
+			args = append(args, "request_id", id)
+		}
+	}
+	c.Logger().Debug("plane api request", args...)
+}
@@ -0,0 +1,147 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type logEntry struct {
+	level string
+	msg   string
+	attrs map[string]any
+}
+
+// recordingLogger collects log entries for inspection
+type recordingLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (l *recordingLogger) record(level, msg string, args []any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	attrs := map[string]any{}
+	for i := 0; i+1 < len(args); i += 2 {
+		attrs[fmt.Sprint(args[i])] = args[i+1]
+	}
+	l.entries = append(l.entries, logEntry{level: level, msg: msg, attrs: attrs})
+}
+
+func (l *recordingLogger) Debug(msg string, args ...any) { l.record("DEBUG", msg, args) }
+func (l *recordingLogger) Info(msg string, args ...any)  { l.record("INFO", msg, args) }
+func (l *recordingLogger) Warn(msg string, args ...any)  { l.record("WARN", msg, args) }
+func (l *recordingLogger) Error(msg string, args ...any) { l.record("ERROR", msg, args) }
+
+// TestLogger tests structured logging of requests
+// 测试请求的结构化日志
+func TestLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"results":"` + strings.Repeat("x", 200) + `"}`))
+	}))
+	defer server.Close()
+
+	t.Run("RequestFields", func(t *testing.T) {
+		logger := &recordingLogger{}
+		c := NewClient("secret-key")
+		c.SetBaseURL(server.URL)
+		c.SetLogger(logger)
+
+		req, _ := c.NewRequest(http.MethodGet, "/ok/", nil)
+		_, err := c.Do(req, nil)
+		assert.NoError(t, err)
+
+		req, _ = c.NewRequest(http.MethodGet, "/missing/", nil)
+		_, err = c.Do(req, nil)
+		assert.Error(t, err)
+
+		assert.Len(t, logger.entries, 2)
+		assert.Equal(t, "DEBUG", logger.entries[0].level)
+		assert.Equal(t, http.MethodGet, logger.entries[0].attrs["method"])
+		assert.Equal(t, "/ok/", logger.entries[0].attrs["path"])
+		assert.Equal(t, http.StatusOK, logger.entries[0].attrs["status"])
+		assert.Contains(t, logger.entries[0].attrs, "latency")
+		assert.Equal(t, "DEBUG", logger.entries[1].level)
+		assert.Equal(t, http.StatusNotFound, logger.entries[1].attrs["status"])
+	})
+
+	t.Run("DebugDumpRedactedAndTruncated", func(t *testing.T) {
+		logger := &recordingLogger{}
+		c := NewClient("secret-key")
+		c.SetBaseURL(server.URL)
+		c.SetLogger(logger)
+		c.SetDebug(true)
+		c.SetLogBodyLimit(100)
+
+		req, _ := c.NewRequest(http.MethodGet, "/ok/", nil)
+		_, err := c.Do(req, nil)
+		assert.NoError(t, err)
+
+		for _, entry := range logger.entries {
+			dump, ok := entry.attrs["dump"].(string)
+			if !ok {
+				continue
+			}
+			assert.NotContains(t, dump, "secret-key")
+			assert.LessOrEqual(t, len(dump), 140)
+		}
+	})
+
+	t.Run("DumpAfterMiddleware", func(t *testing.T) {
+		logger := &recordingLogger{}
+		c := NewClient("secret-key")
+		c.SetBaseURL(server.URL)
+		c.SetLogger(logger)
+		c.SetDebug(true)
+		c.Use(BeforeRequest(func(req *http.Request) error {
+			req.Header.Set("X-Trace-Id", "trace-1")
+			req.Header.Set("Authorization", "Bearer middleware-secret")
+			return nil
+		}))
+
+		req, _ := c.NewRequest(http.MethodGet, "/ok/", nil)
+		_, err := c.Do(req, nil)
+		assert.NoError(t, err)
+
+		var dumps []string
+		for _, entry := range logger.entries {
+			if entry.msg == "plane api request dump" {
+				dumps = append(dumps, entry.attrs["dump"].(string))
+			}
+		}
+		if assert.Len(t, dumps, 1) {
+			assert.Contains(t, dumps[0], "X-Trace-Id: trace-1")
+			assert.Contains(t, dumps[0], "Authorization: [REDACTED]")
+			assert.NotContains(t, dumps[0], "middleware-secret")
+		}
+	})
+
+	t.Run("DefaultLogger", func(t *testing.T) {
+		var out, errOut bytes.Buffer
+		logger := &defaultLogger{out: &out, errOut: &errOut}
+		logger.Debug("hidden")
+		logger.Warn("slow", "latency", "2s")
+		logger.Error("failed")
+		assert.Empty(t, out.String())
+		assert.Equal(t, "WARN slow latency=2s\nERROR failed\n", errOut.String())
+
+		logger.debug = true
+		logger.Debug("shown")
+		assert.Equal(t, "DEBUG shown\n", out.String())
+	})
+
+	t.Run("Redact", func(t *testing.T) {
+		dump := redact([]byte("GET / HTTP/1.1\r\nX-Api-Key: secret-key\r\nAccept: application/json\r\n"))
+		assert.Equal(t, "GET / HTTP/1.1\r\nX-Api-Key: [REDACTED]\r\nAccept: application/json\r\n", dump)
+	})
+}
@@ -0,0 +1,68 @@
+package client
+
+import "net/http"
+
+// Handler sends an API request and returns its response
+type Handler func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps a Handler to run code before and after every API request.
+// A middleware can modify the request before calling next, inspect or replace
+// the response it returns, or short-circuit by returning without calling next.
+// The innermost handler performs the request including retries and rate limiting.
+//
+//	func Timing(next client.Handler) client.Handler {
+//		return func(req *http.Request) (*http.Response, error) {
+//			start := time.Now()
+//			resp, err := next(req)
+//			observe(req.Method, time.Since(start))
+//			return resp, err
+//		}
+//	}
+type Middleware func(next Handler) Handler
+
+// Use appends middlewares to the client. Middlewares run in the order they
+// were added, the first one being the outermost.
+func (c *Client) Use(middlewares ...Middleware) {
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
+// BeforeRequest returns a middleware calling fn before each request is sent.
+// If fn returns an error, the request is not sent and Do returns the error.
+func BeforeRequest(fn func(req *http.Request) error) Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			if err := fn(req); err != nil {
+				return nil, err
+			}
+			return next(req)
+		}
+	}
+}
+
+// AfterResponse returns a middleware calling fn with every response received.
+// If fn returns an error, Do returns it instead of processing the response.
+// fn is not called when the handler returns no response.
+func AfterResponse(fn func(req *http.Request, resp *http.Response) error) Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			resp, err := next(req)
+			if err != nil || resp == nil {
+				return resp, err
+			}
+			if err := fn(req, resp); err != nil {
+				resp.Body.Close()
+				return nil, err
+			}
+			return resp, nil
+		}
+	}
+}
+
+// handler builds the middleware chain around send
+func (c *Client) handler() Handler {
+	h := Handler(c.send)
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		h = c.middlewares[i](h)
+	}
+	return h
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMiddleware tests the middleware chain around Do
+// 测试 Do 的中间件链
+func TestMiddleware(t *testing.T) {
+	var traceHeader string
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		traceHeader = r.Header.Get("X-Trace-ID")
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+
+	t.Run("Order", func(t *testing.T) {
+		var order []string
+		record := func(name string) Middleware {
+			return func(next Handler) Handler {
+				return func(req *http.Request) (*http.Response, error) {
+					order = append(order, name+" before")
+					resp, err := next(req)
+					order = append(order, name+" after")
+					return resp, err
+				}
+			}
+		}
+
+		c := NewClient("test-key")
+		c.SetBaseURL(server.URL)
+		c.Use(record("outer"), record("inner"))
+
+		req, _ := c.NewRequest(http.MethodGet, "/test/", nil)
+		_, err := c.Do(req, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"outer before", "inner before", "inner after", "outer after"}, order)
+	})
+
+	t.Run("BeforeRequest", func(t *testing.T) {
+		c := NewClient("test-key")
+		c.SetBaseURL(server.URL)
+		c.Use(BeforeRequest(func(req *http.Request) error {
+			req.Header.Set("X-Trace-ID", "trace-1")
+			return nil
+		}))
+
+		req, _ := c.NewRequest(http.MethodGet, "/test/", nil)
+		_, err := c.Do(req, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "trace-1", traceHeader)
+	})
+
+	t.Run("BeforeRequestAborts", func(t *testing.T) {
+		calls = 0
+		denied := errors.New("denied")
+		c := NewClient("test-key")
+		c.SetBaseURL(server.URL)
+		c.Use(BeforeRequest(func(req *http.Request) error { return denied }))
+
+		req, _ := c.NewRequest(http.MethodDelete, "/test/", nil)
+		_, err := c.Do(req, nil)
+		assert.ErrorIs(t, err, denied)
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("AfterResponse", func(t *testing.T) {
+		var status int
+		c := NewClient("test-key")
+		c.SetBaseURL(server.URL)
+		c.Use(AfterResponse(func(req *http.Request, resp *http.Response) error {
+			status = resp.StatusCode
+			return nil
+		}))
+
+		req, _ := c.NewRequest(http.MethodGet, "/test/", nil)
+		_, err := c.Do(req, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+	})
+
+	t.Run("ShortCircuit", func(t *testing.T) {
+		calls = 0
+		c := NewClient("test-key")
+		c.SetBaseURL(server.URL)
+		c.Use(func(next Handler) Handler {
+			return func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{},
+					Body:       io.NopCloser(strings.NewReader(`{"id":"cached"}`)),
+					Request:    req,
+				}, nil
+			}
+		})
+
+		req, _ := c.NewRequest(http.MethodGet, "/test/", nil)
+		var v struct {
+			ID string `json:"id"`
+		}
+		_, err := c.Do(req, &v)
+		assert.NoError(t, err)
+		assert.Equal(t, "cached", v.ID)
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("ShortCircuitWithoutBody", func(t *testing.T) {
+		calls = 0
+		c := NewClient("test-key")
+		c.SetBaseURL(server.URL)
+		c.Use(func(next Handler) Handler {
+			return func(req *http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: http.StatusNoContent, Header: http.Header{}, Request: req}, nil
+			}
+		})
+
+		req, _ := c.NewRequest(http.MethodDelete, "/test/", nil)
+		resp, err := c.Do(req, nil)
+		if assert.NoError(t, err) {
+			assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		}
+
+		c = NewClient("test-key")
+		c.SetBaseURL(server.URL)
+		c.Use(func(next Handler) Handler {
+			return func(req *http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}, Request: req}, nil
+			}
+		})
+		req, _ = c.NewRequest(http.MethodGet, "/test/", nil)
+		_, err = c.Do(req, nil)
+		var apiErr *APIError
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("AfterResponseWithoutResponse", func(t *testing.T) {
+		var called bool
+		c := NewClient("test-key")
+		c.SetBaseURL(server.URL)
+		c.Use(AfterResponse(func(req *http.Request, resp *http.Response) error {
+			called = true
+			return nil
+		}), func(next Handler) Handler {
+			return func(req *http.Request) (*http.Response, error) { return nil, nil }
+		})
+
+		req, _ := c.NewRequest(http.MethodGet, "/test/", nil)
+		_, err := c.Do(req, nil)
+		assert.Error(t, err)
+		assert.False(t, called)
+	})
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket limiting the rate of requests sent by a Client.
+// It is safe for concurrent use, so one limiter can be shared by every service
+// of a client, or even by several clients using the same API key.
+// 令牌桶限流器，可在多个 goroutine 之间共享
+type RateLimiter struct {
+	mu          sync.Mutex
+	rate        float64 // tokens per second
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+// NewRateLimiter creates a limiter allowing requestsPerMinute requests per
+// minute on average, with bursts of up to burst requests. A requestsPerMinute
+// of zero or less sets no local limit, and the limiter only honours the pauses
+// requested by the server through Observe.
+func NewRateLimiter(requestsPerMinute int, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   float64(requestsPerMinute) / 60,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// SetRateLimiter sets the limiter every request goes through. A nil limiter disables limiting.
+func (c *Client) SetRateLimiter(limiter *RateLimiter) {
+	c.limiter = limiter
+}
+
+// Wait blocks until a request may be sent or ctx is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		wait := l.reserve(time.Now())
+		if wait == 0 {
+			return nil
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// reserve takes a token if one is available and returns zero, otherwise it
+// returns how long to wait before trying again
+func (l *RateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+
+	// 没有本地限制时不消耗令牌
+	if l.rate <= 0 {
+		return 0
+	}
+	l.refill(now)
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+func (l *RateLimiter) refill(now time.Time) {
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens += elapsed.Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.last = now
+	}
+}
+
+// Observe adapts the limiter to the X-RateLimit-Remaining and
+// X-RateLimit-Reset headers of a response, so that requests slow down
+// before the server starts rejecting them
+func (l *RateLimiter) Observe(header http.Header) {
+	v := header.Get("X-RateLimit-Remaining")
+	if v == "" {
+		return
+	}
+	remaining, err := strconv.Atoi(v)
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill(now)
+	// 服务器剩余配额少于本地令牌时，以服务器为准
+	if float64(remaining) < l.tokens {
+		l.tokens = float64(remaining)
+	}
+	if remaining <= 0 {
+		if wait, ok := serverWait(header, now); ok {
+			if until := now.Add(wait); until.After(l.pausedUntil) {
+				l.pausedUntil = until
+			}
+		}
+	}
+}
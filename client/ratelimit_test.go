@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRateLimiter tests the token bucket behaviour
+// 测试令牌桶限流行为
+func TestRateLimiter(t *testing.T) {
+	t.Run("Burst", func(t *testing.T) {
+		l := NewRateLimiter(60, 3)
+		now := time.Now()
+		assert.Zero(t, l.reserve(now))
+		assert.Zero(t, l.reserve(now))
+		assert.Zero(t, l.reserve(now))
+		assert.Greater(t, l.reserve(now), time.Duration(0))
+	})
+
+	t.Run("Refill", func(t *testing.T) {
+		l := NewRateLimiter(60, 1)
+		now := time.Now()
+		assert.Zero(t, l.reserve(now))
+		assert.Greater(t, l.reserve(now), time.Duration(0))
+		assert.Zero(t, l.reserve(now.Add(time.Second)))
+	})
+
+	t.Run("NoRate", func(t *testing.T) {
+		for _, rate := range []int{0, -1} {
+			l := NewRateLimiter(rate, 1)
+			now := time.Now()
+			for i := 0; i < 5; i++ {
+				assert.Zero(t, l.reserve(now), rate)
+			}
+
+			// 服务器要求的暂停仍然有效
+			h := http.Header{}
+			h.Set("X-RateLimit-Remaining", "0")
+			h.Set("X-RateLimit-Reset", "5")
+			l.Observe(h)
+			assert.Greater(t, l.reserve(time.Now()), 4*time.Second)
+		}
+	})
+
+	t.Run("ObserveRemaining", func(t *testing.T) {
+		l := NewRateLimiter(60, 10)
+		h := http.Header{}
+		h.Set("X-RateLimit-Remaining", "0")
+		h.Set("X-RateLimit-Reset", "5")
+		l.Observe(h)
+
+		wait := l.reserve(time.Now())
+		assert.Greater(t, wait, 4*time.Second)
+	})
+
+	t.Run("WaitHonorsContext", func(t *testing.T) {
+		l := NewRateLimiter(1, 1)
+		assert.NoError(t, l.Wait(context.Background()))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, l.Wait(ctx), context.DeadlineExceeded)
+	})
+
+	t.Run("Client", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+
+		c := NewClient("test-key")
+		c.SetBaseURL(server.URL)
+		c.SetRateLimiter(NewRateLimiter(600, 1))
+
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			req, _ := c.NewRequest(http.MethodGet, "/test/", nil)
+			_, err := c.Do(req, nil)
+			assert.NoError(t, err)
+		}
+		// 600 次/分钟 = 每 100ms 一个令牌
+		assert.GreaterOrEqual(t, time.Since(start), 180*time.Millisecond)
+	})
+}
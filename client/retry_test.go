@@ -0,0 +1,185 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testRetryPolicy() *RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.MinBackoff = time.Millisecond
+	policy.MaxBackoff = 5 * time.Millisecond
+	return policy
+}
+
+// TestRetry tests the retry behaviour of Do
+// 测试 Do 的重试行为
+func TestRetry(t *testing.T) {
+	t.Run("RetriesUntilSuccess", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{"id":"1"}`))
+		}))
+		defer server.Close()
+
+		c := NewClient("test-key")
+		c.SetBaseURL(server.URL)
+		c.SetRetryPolicy(testRetryPolicy())
+
+		req, err := c.NewRequest(http.MethodGet, "/test/", nil)
+		assert.NoError(t, err)
+
+		var v struct {
+			ID string `json:"id"`
+		}
+		_, err = c.Do(req, &v)
+		assert.NoError(t, err)
+		assert.Equal(t, "1", v.ID)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("GivesUpAfterMaxAttempts", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		c := NewClient("test-key")
+		c.SetBaseURL(server.URL)
+		policy := testRetryPolicy()
+		policy.MaxAttempts = 2
+		c.SetRetryPolicy(policy)
+
+		req, _ := c.NewRequest(http.MethodGet, "/test/", nil)
+		resp, err := c.Do(req, nil)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("DoesNotRetryPostByDefault", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		c := NewClient("test-key")
+		c.SetBaseURL(server.URL)
+		c.SetRetryPolicy(testRetryPolicy())
+
+		req, _ := c.NewRequest(http.MethodPost, "/test/", map[string]string{"name": "x"})
+		_, err := c.Do(req, nil)
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("RetriesPostWhenAllowed", func(t *testing.T) {
+		var calls int32
+		var bodies []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			buf, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(buf))
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer server.Close()
+
+		c := NewClient("test-key")
+		c.SetBaseURL(server.URL)
+		policy := testRetryPolicy()
+		policy.RetryNonIdempotent = true
+		c.SetRetryPolicy(policy)
+
+		req, _ := c.NewRequest(http.MethodPost, "/test/", map[string]string{"name": "x"})
+		_, err := c.Do(req, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+		assert.Equal(t, bodies[0], bodies[1])
+	})
+
+	t.Run("HonorsRetryAfter", func(t *testing.T) {
+		var calls int32
+		var first time.Time
+		var elapsed time.Duration
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				first = time.Now()
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			elapsed = time.Since(first)
+		}))
+		defer server.Close()
+
+		c := NewClient("test-key")
+		c.SetBaseURL(server.URL)
+		c.SetRetryPolicy(testRetryPolicy())
+
+		req, _ := c.NewRequest(http.MethodGet, "/test/", nil)
+		_, err := c.Do(req, nil)
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, elapsed, 900*time.Millisecond)
+	})
+
+	t.Run("NoPolicyNoRetry", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		c := NewClient("test-key")
+		c.SetBaseURL(server.URL)
+
+		req, _ := c.NewRequest(http.MethodGet, "/test/", nil)
+		_, err := c.Do(req, nil)
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+}
+
+// TestServerWait tests parsing of Retry-After and X-RateLimit-Reset headers
+// 测试 Retry-After 和 X-RateLimit-Reset 头的解析
+func TestServerWait(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	h := http.Header{}
+	h.Set("Retry-After", "5")
+	wait, ok := serverWait(h, now)
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, wait)
+
+	h = http.Header{}
+	h.Set("Retry-After", now.Add(10*time.Second).UTC().Format(http.TimeFormat))
+	wait, ok = serverWait(h, now)
+	assert.True(t, ok)
+	assert.Equal(t, 10*time.Second, wait)
+
+	h = http.Header{}
+	h.Set("X-RateLimit-Reset", "1700000030")
+	wait, ok = serverWait(h, now)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, wait)
+
+	_, ok = serverWait(http.Header{}, now)
+	assert.False(t, ok)
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Pathf formats an API path, escaping every segment so that workspace slugs
+// and other identifiers cannot break out of their path component.
+//
+//	client.Pathf("/workspaces/%s/projects/%s/issues/", workspaceSlug, projectID)
+func Pathf(format string, segments ...string) string {
+	args := make([]interface{}, len(segments))
+	for i, segment := range segments {
+		args[i] = url.PathEscape(segment)
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// BuildURL joins the base URL and an API path into a request URL.
+// The base URL may carry a path prefix, e.g. for a self-hosted instance behind
+// a reverse proxy at https://example.com/plane/api/v1. The path is given a
+// trailing slash, which every Plane endpoint expects, and query is merged with
+// any query string already present in path.
+func (c *Client) BuildURL(path string, query url.Values) (string, error) {
+	base, err := url.Parse(c.baseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid base URL %q: %w", c.baseURL, err)
+	}
+
+	rawPath, rawQuery, _ := strings.Cut(path, "?")
+	if !strings.HasPrefix(rawPath, "/") {
+		rawPath = "/" + rawPath
+	}
+	if !strings.HasSuffix(rawPath, "/") {
+		rawPath += "/"
+	}
+
+	joined, err := url.Parse(strings.TrimRight(base.EscapedPath(), "/") + rawPath)
+	if err != nil {
+		return "", fmt.Errorf("invalid path %q: %w", path, err)
+	}
+
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", fmt.Errorf("invalid query in path %q: %w", path, err)
+	}
+	for key, vs := range query {
+		for _, v := range vs {
+			values.Add(key, v)
+		}
+	}
+
+	u := *base
+	u.Path = joined.Path
+	u.RawPath = joined.RawPath
+	u.RawQuery = values.Encode()
+	return u.String(), nil
+}
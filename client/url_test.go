@@ -0,0 +1,44 @@
+package client
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBuildURL tests joining base URLs and API paths
+// 测试基础 URL 与 API 路径的拼接
+func TestBuildURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		path    string
+		query   url.Values
+		want    string
+	}{
+		{"Default", "", "/workspaces/ws/projects/", nil, "https://api.plane.so/api/v1/workspaces/ws/projects/"},
+		{"PathPrefix", "https://plane.example.com/plane/api/v1/", "/workspaces/ws/projects/", nil, "https://plane.example.com/plane/api/v1/workspaces/ws/projects/"},
+		{"TrailingSlashAdded", "https://plane.example.com/api/v1", "/workspaces/ws/projects/p1/labels/l1", nil, "https://plane.example.com/api/v1/workspaces/ws/projects/p1/labels/l1/"},
+		{"NoLeadingSlash", "https://plane.example.com/api/v1", "workspaces/ws/projects/", nil, "https://plane.example.com/api/v1/workspaces/ws/projects/"},
+		{"Query", "https://plane.example.com/api/v1", "/workspaces/ws/projects/p1/issues/?per_page=10", url.Values{"cursor": {"10:1:0"}}, "https://plane.example.com/api/v1/workspaces/ws/projects/p1/issues/?cursor=10%3A1%3A0&per_page=10"},
+		{"EscapedSegment", "https://plane.example.com/api/v1", Pathf("/workspaces/%s/projects/", "my ws/x"), nil, "https://plane.example.com/api/v1/workspaces/my%20ws%2Fx/projects/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient("test-key")
+			c.SetBaseURL(tt.baseURL)
+			got, err := c.BuildURL(tt.path, tt.query)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestPathf tests escaping of path segments
+// 测试路径片段的转义
+func TestPathf(t *testing.T) {
+	assert.Equal(t, "/workspaces/my-ws/issues/WEB-1/", Pathf("/workspaces/%s/issues/%s/", "my-ws", "WEB-1"))
+	assert.Equal(t, "/workspaces/a%2Fb%3Fc/", Pathf("/workspaces/%s/", "a/b?c"))
+}
@@ -0,0 +1,424 @@
+package markdown
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// node is an element or, if tag is empty, a text node of parsed HTML
+type node struct {
+	tag      string
+	attrs    map[string]string
+	text     string
+	children []*node
+}
+
+// parseHTML parses an HTML fragment leniently. Unclosed elements are closed by
+// the end tag of an enclosing element, and end tags without a start tag are ignored.
+func parseHTML(s string) (*node, error) {
+	// 包裹一个根元素, 使末尾的 <hr> 等空元素也能自动闭合
+	decoder := xml.NewDecoder(strings.NewReader("<plane-root>" + s + "</plane-root>"))
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+
+	root := &node{tag: "#root"}
+	stack := []*node{root}
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			if len(root.children) == 1 {
+				root.children = root.children[0].children
+			}
+			return root, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("解析 HTML 失败: %w", err)
+		}
+		parent := stack[len(stack)-1]
+		switch t := token.(type) {
+		case xml.StartElement:
+			n := &node{tag: strings.ToLower(t.Name.Local), attrs: map[string]string{}}
+			for _, attr := range t.Attr {
+				n.attrs[strings.ToLower(attr.Name.Local)] = attr.Value
+			}
+			parent.children = append(parent.children, n)
+			stack = append(stack, n)
+		case xml.EndElement:
+			tag := strings.ToLower(t.Name.Local)
+			for i := len(stack) - 1; i > 0; i-- {
+				if stack[i].tag == tag {
+					stack = stack[:i]
+					break
+				}
+			}
+		case xml.CharData:
+			parent.children = append(parent.children, &node{text: string(t)})
+		}
+	}
+}
+
+// FromHTML converts the HTML of Plane's editor to Markdown
+func FromHTML(html string) (string, error) {
+	root, err := parseHTML(html)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(blocks(root.children), "\n\n"), nil
+}
+
+var blockTags = map[string]bool{
+	"p": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"ul": true, "ol": true, "pre": true, "blockquote": true, "hr": true, "table": true,
+	"div": true, "image-component": true,
+}
+
+// mdBlock is a rendered Markdown block
+type mdBlock struct {
+	text string
+	list bool
+}
+
+// blocks renders nodes as Markdown blocks
+func blocks(nodes []*node) []string {
+	var out []string
+	for _, block := range renderBlocksMarkdown(nodes) {
+		out = append(out, block.text)
+	}
+	return out
+}
+
+// renderBlocksMarkdown renders nodes as Markdown blocks. Inline nodes between
+// blocks form a paragraph.
+func renderBlocksMarkdown(nodes []*node) []mdBlock {
+	var out []mdBlock
+	var pending []*node
+	flush := func() {
+		if text := strings.TrimSpace(inlines(pending)); text != "" {
+			out = append(out, mdBlock{text: escapeLineStart(text)})
+		}
+		pending = nil
+	}
+	for _, n := range nodes {
+		if !blockTags[n.tag] {
+			pending = append(pending, n)
+			continue
+		}
+		flush()
+		if text := renderBlock(n); text != "" {
+			out = append(out, mdBlock{text: text, list: n.tag == "ul" || n.tag == "ol"})
+		}
+	}
+	flush()
+	return out
+}
+
+func renderBlock(n *node) string {
+	switch n.tag {
+	case "p":
+		return escapeLineStart(strings.TrimSpace(inlines(n.children)))
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		level, _ := strconv.Atoi(n.tag[1:])
+		text := strings.ReplaceAll(strings.TrimSpace(inlines(n.children)), "\\\n", " ")
+		return strings.Repeat("#", level) + " " + text
+	case "ul", "ol":
+		return renderListMarkdown(n)
+	case "pre":
+		return renderCodeBlockMarkdown(n)
+	case "blockquote":
+		lines := strings.Split(strings.Join(blocks(n.children), "\n\n"), "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimRight("> "+line, " ")
+		}
+		return strings.Join(lines, "\n")
+	case "hr":
+		return "---"
+	case "table":
+		return renderTableMarkdown(n)
+	case "image-component":
+		return inline(n)
+	default:
+		// div 等容器元素
+		return strings.Join(blocks(n.children), "\n\n")
+	}
+}
+
+func renderListMarkdown(n *node) string {
+	start := 1
+	if value, err := strconv.Atoi(n.attrs["start"]); err == nil {
+		start = value
+	}
+	var items []string
+	index := start
+	for _, li := range n.children {
+		if li.tag != "li" {
+			continue
+		}
+		marker := "- "
+		switch {
+		case n.tag == "ol":
+			marker = fmt.Sprintf("%d. ", index)
+			index++
+		case n.attrs["data-type"] == "taskList" || li.attrs["data-type"] == "taskItem":
+			if li.attrs["data-checked"] == "true" {
+				marker = "- [x] "
+			} else {
+				marker = "- [ ] "
+			}
+		}
+		indent := strings.Repeat(" ", 2)
+		if n.tag == "ol" {
+			indent = strings.Repeat(" ", len(marker))
+		}
+
+		// 列表项中的段落之间空一行, 嵌套列表紧跟在上一块之后
+		var content strings.Builder
+		for i, block := range renderBlocksMarkdown(li.children) {
+			if i > 0 && block.list {
+				content.WriteString("\n")
+			} else if i > 0 {
+				content.WriteString("\n\n")
+			}
+			content.WriteString(block.text)
+		}
+		lines := strings.Split(content.String(), "\n")
+		for i := 1; i < len(lines); i++ {
+			if lines[i] != "" {
+				lines[i] = indent + lines[i]
+			}
+		}
+		items = append(items, strings.TrimRight(marker+lines[0], " ")+strings.Join(append([]string{""}, lines[1:]...), "\n"))
+	}
+	return strings.Join(items, "\n")
+}
+
+func renderCodeBlockMarkdown(n *node) string {
+	language := ""
+	for _, child := range n.children {
+		if child.tag == "code" {
+			for _, class := range strings.Fields(child.attrs["class"]) {
+				if strings.HasPrefix(class, "language-") {
+					language = strings.TrimPrefix(class, "language-")
+				}
+			}
+		}
+	}
+	code := strings.TrimSuffix(textContent(n), "\n")
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	return fence + language + "\n" + code + "\n" + fence
+}
+
+func renderTableMarkdown(n *node) string {
+	var rows [][]string
+	var walk func(n *node)
+	walk = func(n *node) {
+		for _, child := range n.children {
+			if child.tag != "tr" {
+				walk(child)
+				continue
+			}
+			var cells []string
+			for _, cell := range child.children {
+				if cell.tag == "td" || cell.tag == "th" {
+					text := strings.Join(blocks(cell.children), " ")
+					text = strings.NewReplacer("\\\n", " ", "\n", " ", "|", `\|`).Replace(text)
+					cells = append(cells, text)
+				}
+			}
+			rows = append(rows, cells)
+		}
+	}
+	walk(n)
+	if len(rows) == 0 {
+		return ""
+	}
+
+	columns := 0
+	for _, row := range rows {
+		if len(row) > columns {
+			columns = len(row)
+		}
+	}
+	var b strings.Builder
+	for i, row := range rows {
+		for len(row) < columns {
+			row = append(row, "")
+		}
+		b.WriteString("| " + strings.Join(row, " | ") + " |")
+		if i == 0 {
+			b.WriteString("\n|" + strings.Repeat(" --- |", columns))
+		}
+		if i < len(rows)-1 {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// inlines renders nodes as inline Markdown
+func inlines(nodes []*node) string {
+	var b strings.Builder
+	for _, n := range nodes {
+		b.WriteString(inline(n))
+	}
+	return b.String()
+}
+
+var (
+	whitespacePattern = regexp.MustCompile(`[ \t\r\n]+`)
+	tagPattern        = regexp.MustCompile(`<[^>]*>`)
+)
+
+func inline(n *node) string {
+	switch n.tag {
+	case "":
+		return escapeMarkdown(whitespacePattern.ReplaceAllString(n.text, " "))
+	case "strong", "b":
+		return wrapInline(inlines(n.children), "**")
+	case "em", "i":
+		return wrapInline(inlines(n.children), "*")
+	case "s", "del", "strike":
+		return wrapInline(inlines(n.children), "~~")
+	case "code":
+		return codeSpan(textContent(n))
+	case "br":
+		return "\\\n"
+	case "a":
+		text := inlines(n.children)
+		if strings.TrimSpace(text) == "" {
+			text = escapeMarkdown(n.attrs["href"])
+		}
+		return "[" + text + "](" + linkDestination(n.attrs["href"]) + ")"
+	case "img", "image-component":
+		return "![" + escapeMarkdown(n.attrs["alt"]) + "](" + linkDestination(n.attrs["src"]) + ")"
+	case "mention-component":
+		label := n.attrs["label"]
+		if label == "" {
+			label = n.attrs["entity_identifier"]
+		}
+		return "[@" + escapeMarkdown(label) + "](" + MentionScheme + n.attrs["entity_identifier"] + ")"
+	case "input", "label":
+		// 任务列表项中的复选框
+		return ""
+	default:
+		if blockTags[n.tag] {
+			return strings.Join(blocks([]*node{n}), " ")
+		}
+		return inlines(n.children)
+	}
+}
+
+// wrapInline wraps text in delimiters, keeping surrounding whitespace outside of them
+func wrapInline(text string, delimiter string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	leading := text[:strings.Index(text, trimmed)]
+	trailing := text[len(leading)+len(trimmed):]
+	return leading + delimiter + trimmed + delimiter + trailing
+}
+
+func codeSpan(code string) string {
+	fence := "`"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+		code = " " + code + " "
+	}
+	return fence + code + fence
+}
+
+func linkDestination(url string) string {
+	if strings.ContainsAny(url, " ()<>") {
+		return "<" + strings.NewReplacer("<", "%3C", ">", "%3E").Replace(url) + ">"
+	}
+	return url
+}
+
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", `\<`, "~~", `\~\~`)
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+var blockStartPattern = regexp.MustCompile(`^(#{1,6}(?: |$)|[-+>]|[0-9]{1,9}[.)]( |$))`)
+
+// escapeLineStart escapes text at the start of a paragraph that would be read as another block
+func escapeLineStart(s string) string {
+	if m := blockStartPattern.FindStringIndex(s); m != nil {
+		if s[0] >= '0' && s[0] <= '9' {
+			i := strings.IndexAny(s, ".)")
+			return s[:i] + `\` + s[i:]
+		}
+		return `\` + s
+	}
+	return s
+}
+
+// textContent returns the text of a node and its descendants
+func textContent(n *node) string {
+	if n.tag == "" {
+		return n.text
+	}
+	var b strings.Builder
+	for _, child := range n.children {
+		b.WriteString(textContent(child))
+	}
+	return b.String()
+}
+
+// Strip returns the plain text of HTML, as stored in description_stripped.
+// Blocks are separated by line breaks and mentions are written as @label.
+func Strip(html string) string {
+	root, err := parseHTML(html)
+	if err != nil {
+		// 无法解析时只去掉标签
+		return strings.TrimSpace(tagPattern.ReplaceAllString(html, " "))
+	}
+	var b strings.Builder
+	var walk func(n *node, inCell bool)
+	walk = func(n *node, inCell bool) {
+		block := !inCell && (blockTags[n.tag] || n.tag == "li")
+		switch {
+		case n.tag == "":
+			b.WriteString(n.text)
+			return
+		case n.tag == "mention-component":
+			label := n.attrs["label"]
+			if label == "" {
+				label = n.attrs["entity_identifier"]
+			}
+			b.WriteString("@" + label)
+			return
+		case block || n.tag == "br" || n.tag == "tr":
+			b.WriteString("\n")
+		case n.tag == "td" || n.tag == "th":
+			b.WriteString(" ")
+			inCell = true
+		}
+		for _, child := range n.children {
+			walk(child, inCell)
+		}
+		if block {
+			b.WriteString("\n")
+		}
+	}
+	walk(root, false)
+
+	var lines []string
+	for _, line := range strings.Split(b.String(), "\n") {
+		if line = strings.TrimSpace(whitespacePattern.ReplaceAllString(line, " ")); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
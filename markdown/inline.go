@@ -0,0 +1,290 @@
+package markdown
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MentionScheme is the URL scheme of mention links such as [@Alice](mention:<member-id>)
+const MentionScheme = "mention:"
+
+// renderInline renders the inline Markdown of a block: code spans, emphasis,
+// strikethrough, links, images, mentions and autolinks
+func renderInline(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && isPunct(s[i+1]):
+			b.WriteString(escapeHTML(s[i+1 : i+2]))
+			i += 2
+		case c == '`':
+			i = renderCodeSpan(&b, s, i)
+		case c == '!' && i+1 < len(s) && s[i+1] == '[':
+			if text, dest, end, ok := parseLink(s, i+1); ok && safeURL(dest) {
+				fmt.Fprintf(&b, `<img src="%s" alt="%s">`, escapeHTML(dest), escapeHTML(plainText(text)))
+				i = end
+			} else {
+				b.WriteByte('!')
+				i++
+			}
+		case c == '[':
+			text, dest, end, ok := parseLink(s, i)
+			switch {
+			case !ok:
+				b.WriteByte('[')
+				i++
+				continue
+			case strings.HasPrefix(dest, MentionScheme):
+				b.WriteString(Mention(strings.TrimPrefix(dest, MentionScheme), strings.TrimPrefix(plainText(text), "@")))
+			case !safeURL(dest):
+				// 不允许的链接协议如 javascript: 按原文输出
+				b.WriteString(escapeHTML(s[i:end]))
+			default:
+				fmt.Fprintf(&b, `<a href="%s">%s</a>`, escapeHTML(dest), renderInline(text))
+			}
+			i = end
+		case c == '<':
+			if end := strings.IndexByte(s[i:], '>'); end > 0 && isAutolink(s[i+1:i+end]) {
+				target := s[i+1 : i+end]
+				href := target
+				if !strings.Contains(target, "://") {
+					href = "mailto:" + target
+				}
+				if safeURL(href) {
+					fmt.Fprintf(&b, `<a href="%s">%s</a>`, escapeHTML(href), escapeHTML(target))
+				} else {
+					b.WriteString(escapeHTML(s[i : i+end+1]))
+				}
+				i += end + 1
+			} else {
+				b.WriteString("&lt;")
+				i++
+			}
+		case c == '*' || c == '_' || c == '~':
+			i = renderEmphasis(&b, s, i)
+		default:
+			b.WriteString(escapeHTML(s[i : i+1]))
+			i++
+		}
+	}
+	return b.String()
+}
+
+// renderCodeSpan renders the code span starting at s[i], or the backticks as text if it is not closed
+func renderCodeSpan(b *strings.Builder, s string, i int) int {
+	n := runLength(s, i)
+	for j := i + n; j < len(s); {
+		if s[j] != '`' {
+			j++
+			continue
+		}
+		m := runLength(s, j)
+		if m == n {
+			code := strings.ReplaceAll(s[i+n:j], "\n", " ")
+			if len(code) > 1 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.Trim(code, " ") != "" {
+				code = code[1 : len(code)-1]
+			}
+			b.WriteString("<code>" + escapeHTML(code) + "</code>")
+			return j + m
+		}
+		j += m
+	}
+	b.WriteString(s[i : i+n])
+	return i + n
+}
+
+// renderEmphasis renders emphasis (* or _), strong emphasis (** or __) or
+// strikethrough (~~) starting at s[i], or the delimiters as text if they are not closed
+func renderEmphasis(b *strings.Builder, s string, i int) int {
+	c := s[i]
+	n := runLength(s, i)
+	opens := i+n < len(s) && !isSpace(s[i+n]) && !(c == '_' && i > 0 && isAlnum(s[i-1]))
+
+	width := 1
+	if n >= 2 {
+		width = 2
+	}
+	if c == '~' && n != 2 {
+		opens = false
+	}
+	if opens {
+		if j := findCloser(s, i+width, c, width); j >= 0 {
+			tag := map[int]string{1: "em", 2: "strong"}[width]
+			if c == '~' {
+				tag = "s"
+			}
+			b.WriteString("<" + tag + ">" + renderInline(s[i+width:j]) + "</" + tag + ">")
+			return j + width
+		}
+	}
+	b.WriteString(s[i : i+n])
+	return i + n
+}
+
+// findCloser returns the index of the delimiter run of width characters c that
+// closes emphasis whose content starts at s[from], or -1
+func findCloser(s string, from int, c byte, width int) int {
+	for j := from; j < len(s); {
+		switch s[j] {
+		case '\\':
+			j += 2
+			continue
+		case '`':
+			// 代码中的分隔符不算
+			if end := strings.Index(s[j+runLength(s, j):], s[j:j+runLength(s, j)]); end >= 0 {
+				j += 2*runLength(s, j) + end
+				continue
+			}
+		case c:
+			m := runLength(s, j)
+			closes := j > from && !isSpace(s[j-1]) && !(c == '_' && j+m < len(s) && isAlnum(s[j+m]))
+			if closes && (m == width || (width == 1 && m >= 3) || (width == 2 && m >= 3)) {
+				// 如 ***x***, 外层的强调从分隔符串的末尾闭合
+				return j + m - width
+			}
+			j += m
+			continue
+		}
+		j++
+	}
+	return -1
+}
+
+// parseLink parses a link [text](destination "title") starting at s[i] and
+// returns its text and destination and the index after it
+func parseLink(s string, i int) (text string, dest string, end int, ok bool) {
+	depth := 0
+	j := i
+	for ; j < len(s); j++ {
+		if s[j] == '\\' {
+			j++
+			continue
+		}
+		if s[j] == '[' {
+			depth++
+		} else if s[j] == ']' {
+			depth--
+			if depth == 0 {
+				break
+			}
+		}
+	}
+	if j+1 >= len(s) || s[j+1] != '(' {
+		return "", "", 0, false
+	}
+	text = s[i+1 : j]
+
+	k := j + 2
+	if k < len(s) && s[k] == '<' {
+		close := strings.IndexByte(s[k:], '>')
+		if close < 0 {
+			return "", "", 0, false
+		}
+		dest = s[k+1 : k+close]
+		k += close + 1
+	} else {
+		parens := 0
+		start := k
+		for ; k < len(s) && s[k] != ' '; k++ {
+			if s[k] == '(' {
+				parens++
+			} else if s[k] == ')' {
+				if parens == 0 {
+					break
+				}
+				parens--
+			}
+		}
+		dest = s[start:k]
+	}
+	// 忽略链接标题
+	for k < len(s) && s[k] != ')' {
+		if s[k] == '"' {
+			if close := strings.IndexByte(s[k+1:], '"'); close >= 0 {
+				k += close + 2
+				continue
+			}
+		}
+		k++
+	}
+	if k >= len(s) {
+		return "", "", 0, false
+	}
+	return text, dest, k + 1, true
+}
+
+// isAutolink reports whether s, the text between < and >, is a URL or an email address
+func isAutolink(s string) bool {
+	if strings.ContainsAny(s, " <>") || s == "" {
+		return false
+	}
+	if scheme := strings.Index(s, "://"); scheme > 0 {
+		return true
+	}
+	at := strings.IndexByte(s, '@')
+	return at > 0 && strings.Contains(s[at:], ".")
+}
+
+// safeURL reports whether a link or image destination is a relative URL or
+// uses the http, https or mailto scheme
+func safeURL(dest string) bool {
+	// 浏览器会忽略协议名中的空白和控制字符
+	url := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, dest)
+	colon := strings.IndexByte(url, ':')
+	if colon < 0 || strings.ContainsAny(url[:colon], "/?#") {
+		return true
+	}
+	switch strings.ToLower(url[:colon]) {
+	case "http", "https", "mailto":
+		return true
+	}
+	return false
+}
+
+// plainText removes the inline markup from the text of a link
+func plainText(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s) && isPunct(s[i+1]):
+			b.WriteByte(s[i+1])
+			i++
+		case strings.IndexByte("*_~`", s[i]) >= 0:
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+func runLength(s string, i int) int {
+	n := 1
+	for i+n < len(s) && s[i+n] == s[i] {
+		n++
+	}
+	return n
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n'
+}
+
+func isAlnum(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+func isPunct(c byte) bool {
+	return c < 0x80 && c > ' ' && !isAlnum(c)
+}
+
+var htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+
+func escapeHTML(s string) string {
+	return htmlEscaper.Replace(s)
+}
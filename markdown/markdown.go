@@ -0,0 +1,340 @@
+// Package markdown converts between Markdown and the HTML of Plane's rich text
+// editor, which issue descriptions and comments are stored as.
+//
+// The supported Markdown is CommonMark without indented code blocks, setext
+// headings and raw HTML, plus the GitHub extensions for tables, task lists and
+// strikethrough. A mention of a member is written as a link with the mention
+// scheme, e.g. [@Alice](mention:<member-id>). Links and images must be relative
+// or use the http, https or mailto scheme; others such as javascript: are
+// written as plain text.
+package markdown
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ToHTML converts Markdown to HTML as produced by Plane's editor
+func ToHTML(md string) string {
+	lines := strings.Split(strings.ReplaceAll(md, "\r\n", "\n"), "\n")
+
+	// 展开缩进中的制表符, 代码块中的保持不变
+	open := ""
+	for i, line := range lines {
+		switch {
+		case open != "":
+			if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, open) && strings.Trim(trimmed, open[:1]) == "" {
+				open = ""
+			}
+		default:
+			indent := len(line) - len(strings.TrimLeft(line, " \t"))
+			lines[i] = strings.ReplaceAll(line[:indent], "\t", "    ") + line[indent:]
+			open = fence(lines[i])
+		}
+	}
+
+	var b strings.Builder
+	renderBlocks(&b, lines)
+	return b.String()
+}
+
+var (
+	headingPattern    = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ ]+(.*?))?(?:[ ]+#+)?[ ]*$`)
+	listItemPattern   = regexp.MustCompile(`^( {0,3})([-*+]|[0-9]{1,9}[.)])( +|$)`)
+	taskPattern       = regexp.MustCompile(`^\[([ xX])\](?: +|$)`)
+	tableDelimPattern = regexp.MustCompile(`^ *\|? *:?-+:? *(\| *:?-+:? *)*\|? *$`)
+)
+
+// renderBlocks renders lines as a sequence of blocks
+func renderBlocks(b *strings.Builder, lines []string) {
+	for i := 0; i < len(lines); {
+		switch {
+		case strings.TrimSpace(lines[i]) == "":
+			i++
+		case fence(lines[i]) != "":
+			i = renderCodeBlock(b, lines, i)
+		case headingPattern.MatchString(lines[i]):
+			m := headingPattern.FindStringSubmatch(lines[i])
+			fmt.Fprintf(b, "<h%d>%s</h%d>", len(m[1]), renderInline(strings.TrimSpace(m[2])), len(m[1]))
+			i++
+		case isThematicBreak(lines[i]):
+			b.WriteString("<hr>")
+			i++
+		case isBlockquote(lines[i]):
+			i = renderBlockquote(b, lines, i)
+		case listItemPattern.MatchString(lines[i]):
+			i = renderList(b, lines, i)
+		case isTableStart(lines, i):
+			i = renderTable(b, lines, i)
+		default:
+			i = renderParagraph(b, lines, i)
+		}
+	}
+}
+
+// startsBlock reports whether a line interrupts a paragraph
+func startsBlock(lines []string, i int) bool {
+	line := lines[i]
+	return strings.TrimSpace(line) == "" || fence(line) != "" || headingPattern.MatchString(line) ||
+		isThematicBreak(line) || isBlockquote(line) || listItemPattern.MatchString(line) || isTableStart(lines, i)
+}
+
+// fence returns the opening code fence of a line such as "```", or ""
+func fence(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 {
+		return ""
+	}
+	for _, c := range []string{"`", "~"} {
+		n := len(trimmed) - len(strings.TrimLeft(trimmed, c))
+		if n >= 3 {
+			// 反引号围栏的信息字符串中不能包含反引号
+			if c == "`" && strings.Contains(trimmed[n:], "`") {
+				return ""
+			}
+			return trimmed[:n]
+		}
+	}
+	return ""
+}
+
+func renderCodeBlock(b *strings.Builder, lines []string, i int) int {
+	open := fence(lines[i])
+	indent := len(lines[i]) - len(strings.TrimLeft(lines[i], " "))
+	info := strings.Fields(strings.TrimSpace(strings.TrimLeft(lines[i], " ")[len(open):]))
+
+	var code []string
+	for i++; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trimmed, open) && strings.Trim(trimmed, open[:1]) == "" {
+			i++
+			break
+		}
+		line := lines[i]
+		for n := 0; n < indent && strings.HasPrefix(line, " "); n++ {
+			line = line[1:]
+		}
+		code = append(code, line)
+	}
+
+	if len(info) > 0 {
+		fmt.Fprintf(b, `<pre><code class="language-%s">`, escapeHTML(info[0]))
+	} else {
+		b.WriteString("<pre><code>")
+	}
+	b.WriteString(escapeHTML(strings.Join(code, "\n")))
+	b.WriteString("</code></pre>")
+	return i
+}
+
+func isThematicBreak(line string) bool {
+	if len(line)-len(strings.TrimLeft(line, " ")) > 3 {
+		return false
+	}
+	compact := strings.Join(strings.Fields(line), "")
+	if len(compact) < 3 {
+		return false
+	}
+	return strings.Trim(compact, compact[:1]) == "" && strings.ContainsAny(compact[:1], "-*_")
+}
+
+func isBlockquote(line string) bool {
+	trimmed := strings.TrimLeft(line, " ")
+	return len(line)-len(trimmed) <= 3 && strings.HasPrefix(trimmed, ">")
+}
+
+func renderBlockquote(b *strings.Builder, lines []string, i int) int {
+	var inner []string
+	for ; i < len(lines) && isBlockquote(lines[i]); i++ {
+		line := strings.TrimLeft(lines[i], " ")[1:]
+		inner = append(inner, strings.TrimPrefix(line, " "))
+	}
+	b.WriteString("<blockquote>")
+	renderBlocks(b, inner)
+	b.WriteString("</blockquote>")
+	return i
+}
+
+// listItem is an item of a list with its lines, without the list marker
+type listItem struct {
+	lines   []string
+	task    bool
+	checked bool
+}
+
+func renderList(b *strings.Builder, lines []string, i int) int {
+	first := listItemPattern.FindStringSubmatch(lines[i])
+	ordered := !strings.ContainsAny(first[2], "-*+")
+	delimiter := first[2][len(first[2])-1:]
+
+	var items []listItem
+	for i < len(lines) {
+		m := listItemPattern.FindStringSubmatch(lines[i])
+		if m == nil || strings.ContainsAny(m[2], "-*+") == ordered || m[2][len(m[2])-1:] != delimiter || isThematicBreak(lines[i]) {
+			break
+		}
+		offset := len(m[0])
+		item := listItem{lines: []string{lines[i][offset:]}}
+		if m[3] == "" || len(m[3]) > 4 {
+			// 空条目或标记后超过四个空格时, 内容从标记后的第一个空格之后开始
+			offset = len(m[1]) + len(m[2]) + 1
+			item.lines[0] = strings.TrimPrefix(lines[i][len(m[1])+len(m[2]):], " ")
+		}
+		if !ordered {
+			if task := taskPattern.FindStringSubmatch(item.lines[0]); task != nil {
+				item.task, item.checked = true, task[1] != " "
+				item.lines[0] = item.lines[0][len(task[0]):]
+			}
+		}
+
+		// 后续行: 缩进到内容位置的行属于该条目, 未缩进的行作为段落的延续
+		i++
+		for i < len(lines) {
+			line := lines[i]
+			if strings.TrimSpace(line) == "" {
+				next := i + 1
+				for next < len(lines) && strings.TrimSpace(lines[next]) == "" {
+					next++
+				}
+				if next < len(lines) && indentOf(lines[next]) >= offset {
+					for ; i < next; i++ {
+						item.lines = append(item.lines, "")
+					}
+					continue
+				}
+				break
+			}
+			if indentOf(line) >= offset {
+				item.lines = append(item.lines, line[offset:])
+				i++
+				continue
+			}
+			if strings.TrimSpace(item.lines[len(item.lines)-1]) != "" && !startsBlock(lines, i) {
+				item.lines = append(item.lines, strings.TrimSpace(line))
+				i++
+				continue
+			}
+			break
+		}
+		items = append(items, item)
+
+		// 列表项之间允许空行
+		next := i
+		for next < len(lines) && strings.TrimSpace(lines[next]) == "" {
+			next++
+		}
+		if next > i && next < len(lines) && listItemPattern.MatchString(lines[next]) {
+			i = next
+		}
+	}
+
+	tasks := !ordered && items[0].task
+	start, _ := strconv.Atoi(first[2][:len(first[2])-1])
+	switch {
+	case tasks:
+		b.WriteString(`<ul data-type="taskList">`)
+	case ordered && start != 1:
+		fmt.Fprintf(b, `<ol start="%d">`, start)
+	case ordered:
+		b.WriteString("<ol>")
+	default:
+		b.WriteString("<ul>")
+	}
+	for _, item := range items {
+		if tasks {
+			fmt.Fprintf(b, `<li data-type="taskItem" data-checked="%t">`, item.checked)
+		} else {
+			b.WriteString("<li>")
+		}
+		renderBlocks(b, item.lines)
+		b.WriteString("</li>")
+	}
+	if ordered {
+		b.WriteString("</ol>")
+	} else {
+		b.WriteString("</ul>")
+	}
+	return i
+}
+
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func isTableStart(lines []string, i int) bool {
+	if i+1 >= len(lines) || !strings.Contains(lines[i], "|") || !tableDelimPattern.MatchString(lines[i+1]) {
+		return false
+	}
+	return len(splitTableRow(lines[i])) == len(splitTableRow(lines[i+1]))
+}
+
+// splitTableRow splits a table row into its cells at unescaped pipes
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+func renderTable(b *strings.Builder, lines []string, i int) int {
+	header := splitTableRow(lines[i])
+	b.WriteString("<table><tbody>")
+	renderTableRow(b, header, len(header), "th")
+	for i += 2; i < len(lines) && strings.TrimSpace(lines[i]) != "" && strings.Contains(lines[i], "|"); i++ {
+		renderTableRow(b, splitTableRow(lines[i]), len(header), "td")
+	}
+	b.WriteString("</tbody></table>")
+	return i
+}
+
+func renderTableRow(b *strings.Builder, cells []string, columns int, tag string) {
+	b.WriteString("<tr>")
+	for i := 0; i < columns; i++ {
+		cell := ""
+		if i < len(cells) {
+			cell = cells[i]
+		}
+		fmt.Fprintf(b, "<%s><p>%s</p></%s>", tag, renderInline(cell), tag)
+	}
+	b.WriteString("</tr>")
+}
+
+// renderParagraph renders lines up to the next block as a paragraph. A line
+// ending in two spaces or a backslash is followed by a hard line break.
+func renderParagraph(b *strings.Builder, lines []string, i int) int {
+	var text string
+	for start := i; i < len(lines) && (i == start || !startsBlock(lines, i)); i++ {
+		switch {
+		case i == start:
+		case strings.HasSuffix(text, "\\"):
+			text = text[:len(text)-1] + "\n"
+		case strings.HasSuffix(text, "  "):
+			text = strings.TrimRight(text, " ") + "\n"
+		default:
+			text = strings.TrimRight(text, " ") + " "
+		}
+		text += strings.TrimLeft(lines[i], " ")
+	}
+	b.WriteString("<p>")
+	b.WriteString(strings.ReplaceAll(renderInline(strings.TrimRight(text, " ")), "\n", "<br>"))
+	b.WriteString("</p>")
+	return i
+}
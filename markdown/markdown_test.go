@@ -0,0 +1,124 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestToHTML tests converting Markdown to the HTML of Plane's editor
+// 测试将 Markdown 转换为 Plane 编辑器的 HTML
+func TestToHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		md   string
+		html string
+	}{
+		{"Paragraphs", "one\ntwo\n\nthree", "<p>one two</p><p>three</p>"},
+		{"HardBreak", "one  \ntwo\\\nthree", "<p>one<br>two<br>three</p>"},
+		{"Heading", "## Title ##", "<h2>Title</h2>"},
+		{"Emphasis", "**bold**, *em*, _em_, ***both***, ~~gone~~", "<p><strong>bold</strong>, <em>em</em>, <em>em</em>, <strong><em>both</em></strong>, <s>gone</s></p>"},
+		{"Literal", "2 * 3, snake_case_name, a ~ b", "<p>2 * 3, snake_case_name, a ~ b</p>"},
+		{"Escapes", `\*not em\* & <b>`, "<p>*not em* &amp; &lt;b&gt;</p>"},
+		{"CodeSpan", "use `a *b* <c>` here", "<p>use <code>a *b* &lt;c&gt;</code> here</p>"},
+		{"Links", `[Plane](https://plane.so "title") <https://go.dev> <me@example.com>`,
+			`<p><a href="https://plane.so">Plane</a> <a href="https://go.dev">https://go.dev</a> <a href="mailto:me@example.com">me@example.com</a></p>`},
+		{"RelativeLink", "[docs](/docs/setup#install) [mail](MAILTO:me@example.com)",
+			`<p><a href="/docs/setup#install">docs</a> <a href="MAILTO:me@example.com">mail</a></p>`},
+		{"UnsafeLinks", "[x](javascript:alert(1)) [y](JavaScript:alert(1)) [z](java\tscript:x) ![i](data:image/svg+xml,x) <vbscript://x>",
+			"<p>[x](javascript:alert(1)) [y](JavaScript:alert(1)) [z](java\tscript:x) ![i](data:image/svg+xml,x) &lt;vbscript://x&gt;</p>"},
+		{"Image", "![diagram](https://example.com/a.png)", `<p><img src="https://example.com/a.png" alt="diagram"></p>`},
+		{"Mention", "ping [@Alice](mention:u-1)",
+			`<p>ping <mention-component entity_identifier="u-1" entity_name="user_mention" label="Alice"></mention-component></p>`},
+		{"CodeBlock", "```go\nfunc main() {\n\tfmt.Println(\"<hi>\")\n}\n```",
+			"<pre><code class=\"language-go\">func main() {\n\tfmt.Println(&quot;&lt;hi&gt;&quot;)\n}</code></pre>"},
+		{"Blockquote", "> quoted\n> **text**", "<blockquote><p>quoted <strong>text</strong></p></blockquote>"},
+		{"ThematicBreak", "a\n\n---\n\nb", "<p>a</p><hr><p>b</p>"},
+		{"BulletList", "- a\n- b\n  - nested\n    continued\n- c",
+			"<ul><li><p>a</p></li><li><p>b</p><ul><li><p>nested continued</p></li></ul></li><li><p>c</p></li></ul>"},
+		{"TabIndentedList", "- a\n\t- nested", "<ul><li><p>a</p><ul><li><p>nested</p></li></ul></li></ul>"},
+		{"OrderedList", "3. three\n4. four", `<ol start="3"><li><p>three</p></li><li><p>four</p></li></ol>`},
+		{"TaskList", "- [ ] todo\n- [x] done",
+			`<ul data-type="taskList"><li data-type="taskItem" data-checked="false"><p>todo</p></li><li data-type="taskItem" data-checked="true"><p>done</p></li></ul>`},
+		{"Table", "| Name | Value |\n| --- | :-: |\n| a | `x\\|y` |\n| b |",
+			"<table><tbody><tr><th><p>Name</p></th><th><p>Value</p></th></tr><tr><td><p>a</p></td><td><p><code>x|y</code></p></td></tr><tr><td><p>b</p></td><td><p></p></td></tr></tbody></table>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.html, ToHTML(tt.md))
+		})
+	}
+}
+
+// TestFromHTML tests converting the HTML of Plane's editor to Markdown
+// 测试将 Plane 编辑器的 HTML 转换为 Markdown
+func TestFromHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		md   string
+	}{
+		{"Paragraphs", "<p>one\n  two</p><p></p><p>three<br>four</p>", "one two\n\nthree\\\nfour"},
+		{"Inline", "<p><strong>bold </strong>and <em>em</em>, <s>gone</s>, <u>under</u>, <code>a`b</code></p>",
+			"**bold** and *em*, ~~gone~~, under, ``a`b``"},
+		{"Escapes", "<p>2 * 3 = [6] &amp; snake_case</p>", `2 \* 3 = \[6\] & snake\_case`},
+		{"LineStart", "<p># not a heading</p><p>1. not a list</p>", "\\# not a heading\n\n1\\. not a list"},
+		{"Link", `<p><a href="https://plane.so" target="_blank" rel="noopener noreferrer nofollow">Plane</a></p>`, "[Plane](https://plane.so)"},
+		{"Mention", `<p>cc <mention-component entity_identifier="u-1" entity_name="user_mention"></mention-component></p>`, "cc [@u-1](mention:u-1)"},
+		{"Image", `<image-component src="https://example.com/a.png" width="35%"></image-component>`, "![](https://example.com/a.png)"},
+		{"CodeBlock", `<pre class="code-block"><code class="language-go">x := "&lt;y&gt;"` + "\n" + `</code></pre>`, "```go\nx := \"<y>\"\n```"},
+		{"TaskList", `<ul data-type="taskList"><li data-checked="true" data-type="taskItem"><label><input type="checkbox" checked="checked"><span></span></label><div><p>done</p></div></li><li data-checked="false" data-type="taskItem"><label><input type="checkbox"><span></span></label><div><p>todo</p></div></li></ul>`,
+			"- [x] done\n- [ ] todo"},
+		{"NestedList", `<ol class="list-decimal pl-7 space-y-2"><li><p>one</p><ul class="list-disc pl-7 space-y-2"><li><p>a</p></li></ul></li><li><p>two</p><p>more</p></li></ol>`,
+			"1. one\n   - a\n2. two\n\n   more"},
+		{"Blockquote", "<blockquote><p>a</p><p>b</p></blockquote>", "> a\n>\n> b"},
+		{"Table", `<table><tbody><tr><th colspan="1" rowspan="1"><p>A</p></th><th><p>B</p></th></tr><tr><td><p>x|y</p></td></tr></tbody></table>`,
+			"| A | B |\n| --- | --- |\n| x\\|y |  |"},
+		{"Heading", "<h3>Steps</h3><hr>", "### Steps\n\n---"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md, err := FromHTML(tt.html)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.md, md)
+		})
+	}
+}
+
+// TestRoundTrip tests that Markdown survives a conversion to HTML and back
+// 测试 Markdown 转换为 HTML 后再转换回来保持不变
+func TestRoundTrip(t *testing.T) {
+	md := "# Incident\n\n" +
+		"Service **api** is *down* since `12:00`, see [runbook](https://example.com/runbook).\\\nOwner: [@Alice](mention:u-1)\n\n" +
+		"- [x] Acknowledge\n- [ ] Post update\n\n" +
+		"1. Check logs\n   - app\n   - proxy\n2. Restart\n\n" +
+		"```sh\nkubectl rollout restart deploy/api\n```\n\n" +
+		"> Customers are affected\n\n" +
+		"| Region | Status |\n| --- | --- |\n| eu | down |"
+
+	html := ToHTML(md)
+	back, err := FromHTML(html)
+	if assert.NoError(t, err) {
+		assert.Equal(t, md, back)
+		assert.Equal(t, html, ToHTML(back))
+	}
+}
+
+// TestStrip tests extracting the plain text of HTML
+// 测试提取 HTML 的纯文本
+func TestStrip(t *testing.T) {
+	html := ToHTML("# Title\n\nSome **bold** text &amp; [@Alice](mention:u-1)\n\n- a\n- b\n\n| A | B |\n| - | - |\n| 1 | 2 |")
+	assert.Equal(t, "Title\nSome bold text &amp; @Alice\na\nb\nA B\n1 2", Strip(html))
+	assert.Equal(t, "", Strip(""))
+	assert.Equal(t, "a < b", Strip("<p>a &lt; b</p>"))
+}
+
+// TestMentions tests extracting the IDs of mentioned members from HTML
+// 测试从 HTML 中提取被提及成员的ID
+func TestMentions(t *testing.T) {
+	html := ToHTML("[@Bob](mention:u-2) and [@Alice](mention:u-1), again [@Bob](mention:u-2)") +
+		`<p><mention-component entity_identifier="i-1" entity_name="issue_mention"></mention-component></p>`
+	assert.Equal(t, []string{"u-2", "u-1"}, Mentions(html))
+	assert.Nil(t, Mentions("<p>no mentions</p>"))
+	assert.Equal(t, `<mention-component entity_identifier="u-1" entity_name="user_mention"></mention-component>`, Mention("u-1", ""))
+}
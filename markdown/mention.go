@@ -0,0 +1,51 @@
+package markdown
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Mention returns the mention component of Plane's editor for a member. The
+// label is shown until the editor has loaded the member and may be empty.
+func Mention(memberID string, label string) string {
+	if label == "" {
+		return fmt.Sprintf(`<mention-component entity_identifier="%s" entity_name="user_mention"></mention-component>`, escapeHTML(memberID))
+	}
+	return fmt.Sprintf(`<mention-component entity_identifier="%s" entity_name="user_mention" label="%s"></mention-component>`, escapeHTML(memberID), escapeHTML(label))
+}
+
+// mentionPattern finds the member IDs of mentions in HTML that cannot be parsed
+var mentionPattern = regexp.MustCompile(`<mention-component\b[^>]*\bentity_identifier\s*=\s*["']([^"']+)["']`)
+
+// Mentions returns the IDs of the members mentioned in HTML, such as the
+// CommentHTML of a comment, in the order they are first mentioned
+func Mentions(html string) []string {
+	var ids []string
+	seen := map[string]bool{}
+	add := func(id string) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	root, err := parseHTML(html)
+	if err != nil {
+		for _, m := range mentionPattern.FindAllStringSubmatch(html, -1) {
+			add(m[1])
+		}
+		return ids
+	}
+	var walk func(n *node)
+	walk = func(n *node) {
+		// 其他类型的提及 (如问题) 不是成员
+		if n.tag == "mention-component" && (n.attrs["entity_name"] == "" || n.attrs["entity_name"] == "user_mention") {
+			add(n.attrs["entity_identifier"])
+		}
+		for _, child := range n.children {
+			walk(child)
+		}
+	}
+	walk(root)
+	return ids
+}
@@ -1,12 +1,15 @@
 package models
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"time"
 )
 
 // Common response structures
-// PagedResponse 分页响应的通用结构
-type PagedResponse struct {
+// PaginatedResponse 分页响应的通用结构
+type PaginatedResponse[T any] struct {
 	GroupedBy       interface{} `json:"grouped_by"`
 	SubGroupedBy    interface{} `json:"sub_grouped_by"`
 	TotalCount      int         `json:"total_count"`
@@ -18,27 +21,18 @@ type PagedResponse struct {
 	TotalPages      int         `json:"total_pages"`
 	TotalResults    int         `json:"total_results"`
 	ExtraStats      interface{} `json:"extra_stats"`
-	Results         interface{} `json:"results"`
+	Results         []T         `json:"results"`
 }
 
-// ProjectsResponse 项目列表的分页响应
-type ProjectsResponse struct {
-	GroupedBy       interface{} `json:"grouped_by"`
-	SubGroupedBy    interface{} `json:"sub_grouped_by"`
-	TotalCount      int         `json:"total_count"`
-	NextCursor      string      `json:"next_cursor"`
-	PrevCursor      string      `json:"prev_cursor"`
-	NextPageResults bool        `json:"next_page_results"`
-	PrevPageResults bool        `json:"prev_page_results"`
-	Count           int         `json:"count"`
-	TotalPages      int         `json:"total_pages"`
-	TotalResults    int         `json:"total_results"`
-	ExtraStats      interface{} `json:"extra_stats"`
-	Results         []Project   `json:"results"`
+// HasNextPage reports whether another page can be fetched with NextCursor
+func (r *PaginatedResponse[T]) HasNextPage() bool {
+	return r.NextPageResults && r.NextCursor != ""
 }
 
-// IssuesResponse 问题列表的分页响应
-type IssuesResponse struct {
+// PagedResponse 分页响应的通用结构
+//
+// Deprecated: use PaginatedResponse with a concrete result type.
+type PagedResponse struct {
 	GroupedBy       interface{} `json:"grouped_by"`
 	SubGroupedBy    interface{} `json:"sub_grouped_by"`
 	TotalCount      int         `json:"total_count"`
@@ -50,120 +44,35 @@ type IssuesResponse struct {
 	TotalPages      int         `json:"total_pages"`
 	TotalResults    int         `json:"total_results"`
 	ExtraStats      interface{} `json:"extra_stats"`
-	Results         []Issue     `json:"results"`
+	Results         interface{} `json:"results"`
 }
 
+// ProjectsResponse 项目列表的分页响应
+type ProjectsResponse = PaginatedResponse[Project]
+
+// IssuesResponse 问题列表的分页响应
+type IssuesResponse = PaginatedResponse[Issue]
+
 // CyclesResponse 周期列表的分页响应
-type CyclesResponse struct {
-	GroupedBy       interface{} `json:"grouped_by"`
-	SubGroupedBy    interface{} `json:"sub_grouped_by"`
-	TotalCount      int         `json:"total_count"`
-	NextCursor      string      `json:"next_cursor"`
-	PrevCursor      string      `json:"prev_cursor"`
-	NextPageResults bool        `json:"next_page_results"`
-	PrevPageResults bool        `json:"prev_page_results"`
-	Count           int         `json:"count"`
-	TotalPages      int         `json:"total_pages"`
-	TotalResults    int         `json:"total_results"`
-	ExtraStats      interface{} `json:"extra_stats"`
-	Results         []Cycle     `json:"results"`
-}
+type CyclesResponse = PaginatedResponse[Cycle]
 
 // ModulesResponse 模块列表的分页响应
-type ModulesResponse struct {
-	GroupedBy       interface{} `json:"grouped_by"`
-	SubGroupedBy    interface{} `json:"sub_grouped_by"`
-	TotalCount      int         `json:"total_count"`
-	NextCursor      string      `json:"next_cursor"`
-	PrevCursor      string      `json:"prev_cursor"`
-	NextPageResults bool        `json:"next_page_results"`
-	PrevPageResults bool        `json:"prev_page_results"`
-	Count           int         `json:"count"`
-	TotalPages      int         `json:"total_pages"`
-	TotalResults    int         `json:"total_results"`
-	ExtraStats      interface{} `json:"extra_stats"`
-	Results         []Module    `json:"results"`
-}
+type ModulesResponse = PaginatedResponse[Module]
 
 // AttachmentsResponse 附件列表的分页响应
-type AttachmentsResponse struct {
-	GroupedBy       interface{}  `json:"grouped_by"`
-	SubGroupedBy    interface{}  `json:"sub_grouped_by"`
-	TotalCount      int          `json:"total_count"`
-	NextCursor      string       `json:"next_cursor"`
-	PrevCursor      string       `json:"prev_cursor"`
-	NextPageResults bool         `json:"next_page_results"`
-	PrevPageResults bool         `json:"prev_page_results"`
-	Count           int          `json:"count"`
-	TotalPages      int          `json:"total_pages"`
-	TotalResults    int          `json:"total_results"`
-	ExtraStats      interface{}  `json:"extra_stats"`
-	Results         []Attachment `json:"results"`
-}
+type AttachmentsResponse = PaginatedResponse[Attachment]
 
 // CommentsResponse 评论列表的分页响应
-type CommentsResponse struct {
-	GroupedBy       interface{} `json:"grouped_by"`
-	SubGroupedBy    interface{} `json:"sub_grouped_by"`
-	TotalCount      int         `json:"total_count"`
-	NextCursor      string      `json:"next_cursor"`
-	PrevCursor      string      `json:"prev_cursor"`
-	NextPageResults bool        `json:"next_page_results"`
-	PrevPageResults bool        `json:"prev_page_results"`
-	Count           int         `json:"count"`
-	TotalPages      int         `json:"total_pages"`
-	TotalResults    int         `json:"total_results"`
-	ExtraStats      interface{} `json:"extra_stats"`
-	Results         []Comment   `json:"results"`
-}
+type CommentsResponse = PaginatedResponse[Comment]
 
 // StatesResponse 状态列表的分页响应
-type StatesResponse struct {
-	GroupedBy       interface{} `json:"grouped_by"`
-	SubGroupedBy    interface{} `json:"sub_grouped_by"`
-	TotalCount      int         `json:"total_count"`
-	NextCursor      string      `json:"next_cursor"`
-	PrevCursor      string      `json:"prev_cursor"`
-	NextPageResults bool        `json:"next_page_results"`
-	PrevPageResults bool        `json:"prev_page_results"`
-	Count           int         `json:"count"`
-	TotalPages      int         `json:"total_pages"`
-	TotalResults    int         `json:"total_results"`
-	ExtraStats      interface{} `json:"extra_stats"`
-	Results         []State     `json:"results"`
-}
+type StatesResponse = PaginatedResponse[State]
 
 // LabelsResponse 标签列表的分页响应
-type LabelsResponse struct {
-	GroupedBy       interface{} `json:"grouped_by"`
-	SubGroupedBy    interface{} `json:"sub_grouped_by"`
-	TotalCount      int         `json:"total_count"`
-	NextCursor      string      `json:"next_cursor"`
-	PrevCursor      string      `json:"prev_cursor"`
-	NextPageResults bool        `json:"next_page_results"`
-	PrevPageResults bool        `json:"prev_page_results"`
-	Count           int         `json:"count"`
-	TotalPages      int         `json:"total_pages"`
-	TotalResults    int         `json:"total_results"`
-	ExtraStats      interface{} `json:"extra_stats"`
-	Results         []Label     `json:"results"`
-}
+type LabelsResponse = PaginatedResponse[Label]
 
 // LinksResponse 链接列表的分页响应
-type LinksResponse struct {
-	GroupedBy       interface{} `json:"grouped_by"`
-	SubGroupedBy    interface{} `json:"sub_grouped_by"`
-	TotalCount      int         `json:"total_count"`
-	NextCursor      string      `json:"next_cursor"`
-	PrevCursor      string      `json:"prev_cursor"`
-	NextPageResults bool        `json:"next_page_results"`
-	PrevPageResults bool        `json:"prev_page_results"`
-	Count           int         `json:"count"`
-	TotalPages      int         `json:"total_pages"`
-	TotalResults    int         `json:"total_results"`
-	ExtraStats      interface{} `json:"extra_stats"`
-	Results         []Link      `json:"results"`
-}
+type LinksResponse = PaginatedResponse[Link]
 
 type Pagination struct {
 	Count        int  `json:"count"`
@@ -201,21 +110,162 @@ type Project struct {
 	Workspace   string    `json:"workspace"`
 }
 
-// Issue represents a Plane issue
+// Issue represents a Plane issue.
+// Nullable fields are pointers; dates are formatted as YYYY-MM-DD.
 type Issue struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description,omitempty"`
-	State       string    `json:"state,omitempty"`
-	Priority    string    `json:"priority,omitempty"`
-	AssigneeID  string    `json:"assignee_id,omitempty"`
-	Assignees   []string  `json:"assignees,omitempty"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	CreatedBy   string    `json:"created_by"`
-	UpdatedBy   string    `json:"updated_by"`
-	Project     string    `json:"project"`
-	Workspace   string    `json:"workspace"`
+	ID                  string     `json:"id"`
+	SequenceID          int        `json:"sequence_id"`
+	Name                string     `json:"name"`
+	Description         string     `json:"description,omitempty"`
+	DescriptionHTML     string     `json:"description_html,omitempty"`
+	DescriptionStripped string     `json:"description_stripped,omitempty"`
+	State               string     `json:"state,omitempty"`
+	Priority            string     `json:"priority,omitempty"`
+	AssigneeID          string     `json:"assignee_id,omitempty"`
+	Assignees           []string   `json:"assignees,omitempty"`
+	Labels              []string   `json:"labels,omitempty"`
+	Parent              *string    `json:"parent"`
+	StartDate           *string    `json:"start_date"`
+	TargetDate          *string    `json:"target_date"`
+	EstimatePoint       *string    `json:"estimate_point"`
+	Point               *int       `json:"point"`
+	SortOrder           float64    `json:"sort_order"`
+	CompletedAt         *time.Time `json:"completed_at"`
+	ArchivedAt          *time.Time `json:"archived_at"`
+	IsDraft             bool       `json:"is_draft"`
+	CycleID             *string    `json:"cycle_id,omitempty"`
+	ModuleIDs           []string   `json:"module_ids,omitempty"`
+	TypeID              *string    `json:"type_id"`
+	ExternalSource      *string    `json:"external_source"`
+	ExternalID          *string    `json:"external_id"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+	CreatedBy           string     `json:"created_by"`
+	UpdatedBy           string     `json:"updated_by"`
+	Project             string     `json:"project"`
+	Workspace           string     `json:"workspace"`
+
+	// Expanded objects, set when the issue was requested with expand.
+	// State, Assignees and Labels always hold the IDs.
+	StateDetail     *State       `json:"-"`
+	AssigneeDetails []MemberUser `json:"-"`
+	LabelDetails    []Label      `json:"-"`
+}
+
+// UnmarshalJSON decodes an issue, accepting expanded state, assignees and labels
+func (i *Issue) UnmarshalJSON(data []byte) error {
+	type issue Issue
+	aux := struct {
+		*issue
+		State      json.RawMessage `json:"state"`
+		Assignees  json.RawMessage `json:"assignees"`
+		Labels     json.RawMessage `json:"labels"`
+		ArchivedAt json.RawMessage `json:"archived_at"`
+	}{issue: (*issue)(i)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	archivedAt, err := parseTimestamp(aux.ArchivedAt)
+	if err != nil {
+		return fmt.Errorf("archived_at: %w", err)
+	}
+	i.ArchivedAt = archivedAt
+
+	i.State, i.StateDetail = "", nil
+	if isJSONObject(aux.State) {
+		i.StateDetail = new(State)
+		if err := json.Unmarshal(aux.State, i.StateDetail); err != nil {
+			return err
+		}
+		i.State = i.StateDetail.ID
+	} else if len(aux.State) > 0 {
+		if err := json.Unmarshal(aux.State, &i.State); err != nil {
+			return err
+		}
+	}
+
+	i.Assignees, i.AssigneeDetails = nil, nil
+	if isJSONObjectList(aux.Assignees) {
+		if err := json.Unmarshal(aux.Assignees, &i.AssigneeDetails); err != nil {
+			return err
+		}
+		for _, assignee := range i.AssigneeDetails {
+			i.Assignees = append(i.Assignees, assignee.ID)
+		}
+	} else if len(aux.Assignees) > 0 {
+		if err := json.Unmarshal(aux.Assignees, &i.Assignees); err != nil {
+			return err
+		}
+	}
+
+	i.Labels, i.LabelDetails = nil, nil
+	if isJSONObjectList(aux.Labels) {
+		if err := json.Unmarshal(aux.Labels, &i.LabelDetails); err != nil {
+			return err
+		}
+		for _, label := range i.LabelDetails {
+			i.Labels = append(i.Labels, label.ID)
+		}
+	} else if len(aux.Labels) > 0 {
+		if err := json.Unmarshal(aux.Labels, &i.Labels); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseTimestamp decodes a JSON timestamp, which older Plane versions send as a
+// date such as "2024-01-31", null or a missing field
+func parseTimestamp(data json.RawMessage) (*time.Time, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var value *string
+	if err := json.Unmarshal(data, &value); err != nil || value == nil || *value == "" {
+		return nil, err
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02"} {
+		if t, err := time.Parse(layout, *value); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("无效的时间: %s", *value)
+}
+
+// isJSONObject reports whether data is a JSON object
+func isJSONObject(data json.RawMessage) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && data[0] == '{'
+}
+
+// isJSONObjectList reports whether data is a JSON array starting with an object
+func isJSONObjectList(data json.RawMessage) bool {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '[' {
+		return false
+	}
+	return isJSONObject(data[1:])
+}
+
+// IssueSearchResult is an issue found by the workspace issue search
+type IssueSearchResult struct {
+	ID                string `json:"id"`
+	Name              string `json:"name"`
+	SequenceID        int    `json:"sequence_id"`
+	ProjectIdentifier string `json:"project__identifier"`
+	ProjectID         string `json:"project_id"`
+	WorkspaceSlug     string `json:"workspace__slug"`
+}
+
+// Identifier returns the identifier of the issue shown in the UI, such as "WEB-12"
+func (r *IssueSearchResult) Identifier() string {
+	return fmt.Sprintf("%s-%d", r.ProjectIdentifier, r.SequenceID)
+}
+
+// IssueSearchResponse 问题搜索的响应
+type IssueSearchResponse struct {
+	Issues []IssueSearchResult `json:"issues"`
 }
 
 // Cycle represents a Plane cycle
@@ -276,11 +326,46 @@ type Comment struct {
 	Member *MemberUser `json:"member,omitempty"`
 }
 
+// Activity represents an entry in the history of an issue.
+// For changes, Field names the changed field and OldValue and NewValue hold the
+// displayed values; for references such as the state, OldIdentifier and
+// NewIdentifier hold the IDs.
+type Activity struct {
+	ID            string    `json:"id"`
+	Verb          string    `json:"verb"` // created, updated or deleted
+	Field         string    `json:"field"`
+	OldValue      string    `json:"old_value"`
+	NewValue      string    `json:"new_value"`
+	OldIdentifier string    `json:"old_identifier"`
+	NewIdentifier string    `json:"new_identifier"`
+	Comment       string    `json:"comment"`
+	Actor         string    `json:"actor"`
+	Epoch         float64   `json:"epoch"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	Project       string    `json:"project"`
+	Workspace     string    `json:"workspace"`
+	Issue         string    `json:"issue"`
+}
+
+// ActivitiesResponse 活动列表的分页响应
+type ActivitiesResponse = PaginatedResponse[Activity]
+
+// State groups, which classify the states of a project
+const (
+	StateGroupBacklog   = "backlog"
+	StateGroupUnstarted = "unstarted"
+	StateGroupStarted   = "started"
+	StateGroupCompleted = "completed"
+	StateGroupCancelled = "cancelled"
+)
+
 // State represents a state in the project (e.g., Todo, In Progress, Done)
 type State struct {
 	ID          string    `json:"id"`
 	Name        string    `json:"name"`
 	Color       string    `json:"color"`
+	Group       string    `json:"group,omitempty"` // 状态组, 如 StateGroupStarted
 	Description string    `json:"description,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
@@ -290,6 +375,64 @@ type State struct {
 	Workspace   string    `json:"workspace"`
 }
 
+// Issue relation types. A relation of type RelationBlocking from A to B means
+// that A blocks B, which is the same relation as RelationBlockedBy from B to A.
+const (
+	RelationBlocking  = "blocking"
+	RelationBlockedBy = "blocked_by"
+	RelationDuplicate = "duplicate"
+	RelationRelatesTo = "relates_to"
+)
+
+// IssueRelation represents a relation between two issues
+type IssueRelation struct {
+	ID           string    `json:"id"`
+	Issue        string    `json:"issue"`
+	RelatedIssue string    `json:"related_issue"`
+	RelationType string    `json:"relation_type"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	CreatedBy    string    `json:"created_by"`
+	UpdatedBy    string    `json:"updated_by"`
+	Project      string    `json:"project"`
+	Workspace    string    `json:"workspace"`
+}
+
+// RelatedIssue is an issue in the relations of another issue
+type RelatedIssue struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	SequenceID   int      `json:"sequence_id"`
+	ProjectID    string   `json:"project_id"`
+	StateID      string   `json:"state_id"`
+	Priority     string   `json:"priority"`
+	AssigneeIDs  []string `json:"assignee_ids"`
+	RelationType string   `json:"relation_type"`
+}
+
+// IssueRelations groups the related issues of an issue by relation type
+type IssueRelations struct {
+	Blocking  []RelatedIssue `json:"blocking"`
+	BlockedBy []RelatedIssue `json:"blocked_by"`
+	Duplicate []RelatedIssue `json:"duplicate"`
+	RelatesTo []RelatedIssue `json:"relates_to"`
+}
+
+// Of returns the related issues of the given relation type
+func (r *IssueRelations) Of(relationType string) []RelatedIssue {
+	switch relationType {
+	case RelationBlocking:
+		return r.Blocking
+	case RelationBlockedBy:
+		return r.BlockedBy
+	case RelationDuplicate:
+		return r.Duplicate
+	case RelationRelatesTo:
+		return r.RelatesTo
+	}
+	return nil
+}
+
 // Link represents a link attached to an issue
 type Link struct {
 	ID        string                 `json:"id"`
@@ -363,20 +506,7 @@ type UploadCredentials struct {
 }
 
 // MembersResponse 成员列表的分页响应
-type MembersResponse struct {
-	GroupedBy       interface{} `json:"grouped_by"`
-	SubGroupedBy    interface{} `json:"sub_grouped_by"`
-	TotalCount      int         `json:"total_count"`
-	NextCursor      string      `json:"next_cursor"`
-	PrevCursor      string      `json:"prev_cursor"`
-	NextPageResults bool        `json:"next_page_results"`
-	PrevPageResults bool        `json:"prev_page_results"`
-	Count           int         `json:"count"`
-	TotalPages      int         `json:"total_pages"`
-	TotalResults    int         `json:"total_results"`
-	ExtraStats      interface{} `json:"extra_stats"`
-	Results         []Member    `json:"results"`
-}
+type MembersResponse = PaginatedResponse[Member]
 
 // MemberUser represents a member user information
 type MemberUser struct {
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIssueArchivedAt tests decoding the archive time as a timestamp or a date
+// 测试将归档时间解码为时间戳或日期
+func TestIssueArchivedAt(t *testing.T) {
+	for data, want := range map[string]*time.Time{
+		`{"archived_at": "2024-01-31T10:00:00Z"}`: timePtr(time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)),
+		`{"archived_at": "2024-01-31"}`:           timePtr(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+		`{"archived_at": null}`:                   nil,
+		`{}`:                                      nil,
+	} {
+		var issue Issue
+		if assert.NoError(t, json.Unmarshal([]byte(data), &issue), data) {
+			assert.Equal(t, want, issue.ArchivedAt, data)
+		}
+	}
+
+	var issue Issue
+	assert.Error(t, json.Unmarshal([]byte(`{"archived_at": "yesterday"}`), &issue))
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Nullable is a request field that can be set to a value or explicitly to null.
+// Use it as a pointer field with omitempty so it has three states:
+//
+//	nil                 // field is omitted, the server keeps its value
+//	models.Null[T]()    // field is sent as null, clearing the value
+//	models.Value(v)     // field is sent as v
+//
+// Nullable 用于 PATCH 请求中可以显式清空的字段
+type Nullable[T any] struct {
+	value T
+	valid bool
+}
+
+// Value returns a Nullable set to v
+func Value[T any](v T) *Nullable[T] {
+	return &Nullable[T]{value: v, valid: true}
+}
+
+// Null returns a Nullable set to null
+func Null[T any]() *Nullable[T] {
+	return &Nullable[T]{}
+}
+
+// Get returns the value and whether it is set. It reports false for null.
+func (n *Nullable[T]) Get() (T, bool) {
+	if n == nil {
+		var zero T
+		return zero, false
+	}
+	return n.value, n.valid
+}
+
+// IsNull reports whether n is explicitly null
+func (n *Nullable[T]) IsNull() bool {
+	return n != nil && !n.valid
+}
+
+// MarshalJSON encodes the value, or null
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	if !n.valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.value)
+}
+
+// UnmarshalJSON decodes a value or null
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		var zero T
+		n.value, n.valid = zero, false
+		return nil
+	}
+	if err := json.Unmarshal(data, &n.value); err != nil {
+		return err
+	}
+	n.valid = true
+	return nil
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNullableMarshal tests that unset, null and set fields are encoded differently
+// 测试未设置、null 和有值的字段编码结果不同
+func TestNullableMarshal(t *testing.T) {
+	type request struct {
+		Date *Nullable[string] `json:"date,omitempty"`
+	}
+
+	data, err := json.Marshal(request{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(data))
+
+	data, err = json.Marshal(request{Date: Null[string]()})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"date":null}`, string(data))
+
+	data, err = json.Marshal(request{Date: Value("2024-01-31")})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"date":"2024-01-31"}`, string(data))
+}
+
+// TestNullableUnmarshal tests decoding values and null
+// 测试解码值和 null
+func TestNullableUnmarshal(t *testing.T) {
+	var n Nullable[int]
+	assert.NoError(t, json.Unmarshal([]byte(`3`), &n))
+	v, ok := n.Get()
+	assert.True(t, ok)
+	assert.Equal(t, 3, v)
+
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &n))
+	assert.True(t, n.IsNull())
+	_, ok = n.Get()
+	assert.False(t, ok)
+
+	var unset *Nullable[int]
+	assert.False(t, unset.IsNull())
+}
@@ -1,6 +1,10 @@
 package plane
 
 import (
+	"context"
+	"net/http"
+	"time"
+
 	"github.com/GeekWorkCode/plane-api-go/api"
 	"github.com/GeekWorkCode/plane-api-go/client"
 	"github.com/GeekWorkCode/plane-api-go/models"
@@ -15,8 +19,16 @@ type CommentsService interface {
 	Create(workspaceSlug string, projectID string, issueID string, request *api.CommentRequest) (*models.Comment, error)
 	Update(workspaceSlug string, projectID string, issueID string, commentID string, request *api.CommentRequest) (*models.Comment, error)
 	Delete(workspaceSlug string, projectID string, issueID string, commentID string) error
+
+	ListWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string) ([]models.Comment, error)
+	GetWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, commentID string) (*models.Comment, error)
+	CreateWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, request *api.CommentRequest) (*models.Comment, error)
+	UpdateWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, commentID string, request *api.CommentRequest) (*models.Comment, error)
+	DeleteWithContext(ctx context.Context, workspaceSlug string, projectID string, issueID string, commentID string) error
 }
 
+var _ CommentsService = (*api.CommentsService)(nil)
+
 // Plane is the main API client
 type Plane struct {
 	// Client is the HTTP client used to communicate with the API
@@ -34,13 +46,114 @@ type Plane struct {
 	Attachments *api.AttachmentsService
 	Worklogs    *api.WorklogsService
 	Members     *api.MembersService
+	Relations   *api.IssueRelationsService
+	Activities  *api.ActivitiesService
+	Mover       *api.IssueMover
+
+	// Resolver caches the names of states, members, labels, cycles and modules
+	// for all services of the client
+	Resolver *api.Resolver
+}
+
+// Option configures the underlying HTTP client of a Plane API client
+type Option func(*client.Client)
+
+// WithHTTPClient sets the HTTP client used for API requests and file uploads.
+// A transport or timeout given with WithTransport or WithTimeout is applied
+// to a copy of it.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *client.Client) {
+		c.SetHTTPClient(httpClient)
+	}
+}
+
+// WithTransport sets the transport of the HTTP client, e.g. to use a proxy or mTLS
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *client.Client) {
+		c.SetTransport(transport)
+	}
+}
+
+// WithTimeout sets the timeout of a single HTTP request attempt
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *client.Client) {
+		c.SetTimeout(timeout)
+	}
+}
+
+// WithBaseURL sets the base URL for API requests, e.g. for a self-hosted instance
+func WithBaseURL(baseURL string) Option {
+	return func(c *client.Client) {
+		c.SetBaseURL(baseURL)
+	}
+}
+
+// WithUserAgent appends suffix to the User-Agent header sent with every request
+func WithUserAgent(suffix string) Option {
+	return func(c *client.Client) {
+		c.SetUserAgentSuffix(suffix)
+	}
 }
 
-// NewClient returns a new Plane API client
-func NewClient(apiKey string) *Plane {
+// WithLogger sets the logger receiving the client's log output
+func WithLogger(logger client.Logger) Option {
+	return func(c *client.Client) {
+		c.SetLogger(logger)
+	}
+}
+
+// WithLogBodyLimit truncates request and response dumps logged in debug mode to limit bytes
+func WithLogBodyLimit(limit int) Option {
+	return func(c *client.Client) {
+		c.SetLogBodyLimit(limit)
+	}
+}
+
+// WithRetryPolicy sets the policy used to retry failed requests.
+// Passing client.DefaultRetryPolicy() enables retries of idempotent
+// requests on rate limiting and gateway errors.
+func WithRetryPolicy(policy *client.RetryPolicy) Option {
+	return func(c *client.Client) {
+		c.SetRetryPolicy(policy)
+	}
+}
+
+// WithRateLimit limits the client to requestsPerMinute requests per minute
+// across all services, allowing bursts of up to burst requests.
+// Plane Cloud allows 60 requests per minute per API key. A requestsPerMinute
+// of zero or less sets no limit.
+func WithRateLimit(requestsPerMinute int, burst int) Option {
+	return func(c *client.Client) {
+		c.SetRateLimiter(client.NewRateLimiter(requestsPerMinute, burst))
+	}
+}
+
+// WithRateLimiter makes the client share an existing limiter, e.g. between
+// several clients using the same API key.
+func WithRateLimiter(limiter *client.RateLimiter) Option {
+	return func(c *client.Client) {
+		c.SetRateLimiter(limiter)
+	}
+}
+
+// WithMiddleware adds middlewares that run around every API request,
+// e.g. for tracing, metrics, audit logs or request signing
+func WithMiddleware(middlewares ...client.Middleware) Option {
+	return func(c *client.Client) {
+		c.Use(middlewares...)
+	}
+}
+
+// NewClient returns a new Plane API client.
+// WithTransport and WithTimeout also apply to a client passed to
+// WithHTTPClient, whichever order the options are given in.
+func NewClient(apiKey string, opts ...Option) *Plane {
 	c := client.NewClient(apiKey)
+	for _, opt := range opts {
+		opt(c)
+	}
 
-	return &Plane{
+	p := &Plane{
 		client:      c,
 		Projects:    api.NewProjectsService(c),
 		Issues:      api.NewIssuesService(c),
@@ -53,7 +166,20 @@ func NewClient(apiKey string) *Plane {
 		Attachments: api.NewAttachmentsService(c),
 		Worklogs:    api.NewWorklogsService(c),
 		Members:     api.NewMembersService(c),
+		Relations:   api.NewIssueRelationsService(c),
+		Activities:  api.NewActivitiesService(c),
+		Mover:       api.NewIssueMover(c),
+		Resolver:    api.NewResolver(c, api.DefaultResolverTTL),
 	}
+	p.Issues.SetResolver(p.Resolver)
+	p.Cycles.SetResolver(p.Resolver)
+	p.Modules.SetResolver(p.Resolver)
+	p.Labels.SetResolver(p.Resolver)
+	p.States.SetResolver(p.Resolver)
+	p.Comments.SetResolver(p.Resolver)
+	p.Members.SetResolver(p.Resolver)
+	p.Mover.SetResolver(p.Resolver)
+	return p
 }
 
 // SetDebug enables or disables debug logging
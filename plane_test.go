@@ -0,0 +1,65 @@
+package plane
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type countingTransport struct {
+	calls int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.calls++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// TestNewClientOptions tests that options are applied to the underlying client
+// 测试选项被应用到底层客户端
+func TestNewClientOptions(t *testing.T) {
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.Write([]byte(`{"results":[]}`))
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{}
+	transport := &countingTransport{}
+	p := NewClient("test-key",
+		WithHTTPClient(httpClient),
+		WithTransport(transport),
+		WithTimeout(5*time.Second),
+		WithBaseURL(server.URL+"/"),
+		WithUserAgent("my-service/1.0"),
+	)
+
+	_, err := p.Projects.List("ws")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, transport.calls)
+	assert.Equal(t, "plane-api-go/0.1.0 my-service/1.0", userAgent)
+	assert.Equal(t, 5*time.Second, p.client.HTTPClient().Timeout)
+
+	// 传入的 http.Client 不应被修改
+	assert.Nil(t, httpClient.Transport)
+	assert.Zero(t, httpClient.Timeout)
+
+	// WithHTTPClient 放在后面时不会丢弃传输和超时设置
+	transport = &countingTransport{}
+	p = NewClient("test-key",
+		WithTransport(transport),
+		WithTimeout(5*time.Second),
+		WithBaseURL(server.URL),
+		WithHTTPClient(httpClient),
+	)
+	_, err = p.Projects.List("ws")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, transport.calls)
+	assert.Equal(t, 5*time.Second, p.client.HTTPClient().Timeout)
+	assert.Nil(t, httpClient.Transport)
+	assert.Zero(t, httpClient.Timeout)
+}
@@ -0,0 +1,111 @@
+package planetest
+
+import (
+	"net/http"
+
+	"github.com/GeekWorkCode/plane-api-go/models"
+)
+
+// issueFields are the tracked scalar fields of an issue, by activity field name
+var issueFields = map[string]func(it *models.Issue) string{
+	"name":           func(it *models.Issue) string { return it.Name },
+	"priority":       func(it *models.Issue) string { return it.Priority },
+	"parent":         func(it *models.Issue) string { return deref(it.Parent) },
+	"start_date":     func(it *models.Issue) string { return deref(it.StartDate) },
+	"target_date":    func(it *models.Issue) string { return deref(it.TargetDate) },
+	"estimate_point": func(it *models.Issue) string { return deref(it.EstimatePoint) },
+}
+
+// issueFieldOrder fixes the order in which changes of one update are recorded
+var issueFieldOrder = []string{"name", "priority", "parent", "start_date", "target_date", "estimate_point"}
+
+// addActivity appends an entry to the history of an issue
+func (s *Server) addActivity(it *models.Issue, activity models.Activity) {
+	now := s.now()
+	activity.ID, activity.Actor = newID(), s.UserID
+	activity.Issue, activity.Project, activity.Workspace = it.ID, it.Project, it.Workspace
+	activity.CreatedAt, activity.UpdatedAt = now, now
+	activity.Epoch = float64(now.Unix())
+	s.activities.put(activity.ID, &activity)
+}
+
+// issueUpdated records the history of an update and refreshes the server side fields
+func (s *Server) issueUpdated(old *models.Issue, it *models.Issue) {
+	it.UpdatedAt = s.now()
+
+	if old.State != it.State {
+		oldState, _ := s.states.get(old.State)
+		newState, ok := s.states.get(it.State)
+		activity := models.Activity{Verb: "updated", Field: "state", OldIdentifier: old.State, NewIdentifier: it.State}
+		if oldState != nil {
+			activity.OldValue = oldState.Name
+		}
+		if ok {
+			activity.NewValue = newState.Name
+			// Plane 在进入已完成状态组时记录完成时间
+			if newState.Group == models.StateGroupCompleted {
+				now := s.now()
+				it.CompletedAt = &now
+			} else {
+				it.CompletedAt = nil
+			}
+		}
+		s.addActivity(it, activity)
+	}
+
+	for _, field := range issueFieldOrder {
+		value := issueFields[field]
+		if before, after := value(old), value(it); before != after {
+			s.addActivity(it, models.Activity{Verb: "updated", Field: field, OldValue: before, NewValue: after})
+		}
+	}
+
+	memberName := func(id string) string {
+		if m := s.member(it.Project, id); m != nil {
+			return m.DisplayName
+		}
+		return id
+	}
+	labelName := func(id string) string {
+		if label, ok := s.labels.get(id); ok {
+			return label.Name
+		}
+		return id
+	}
+	s.listChanged(it, "assignees", old.Assignees, it.Assignees, memberName)
+	s.listChanged(it, "labels", old.Labels, it.Labels, labelName)
+}
+
+// listChanged records one activity per ID added to or removed from a list field
+func (s *Server) listChanged(it *models.Issue, field string, before []string, after []string, name func(id string) string) {
+	for _, id := range after {
+		if !contains(before, id) {
+			s.addActivity(it, models.Activity{Verb: "updated", Field: field, NewValue: name(id), NewIdentifier: id})
+		}
+	}
+	for _, id := range before {
+		if !contains(after, id) {
+			s.addActivity(it, models.Activity{Verb: "updated", Field: field, OldValue: name(id), OldIdentifier: id})
+		}
+	}
+}
+
+func (s *Server) listActivities(w http.ResponseWriter, r *http.Request, p params) {
+	if !s.checkScope(w, p) {
+		return
+	}
+	items := s.activities.filter(func(it *models.Activity) bool { return it.Issue == p["issue"] })
+	writePage(s, w, r, items)
+}
+
+func (s *Server) getActivity(w http.ResponseWriter, r *http.Request, p params) {
+	if !s.checkScope(w, p) {
+		return
+	}
+	activity, ok := s.activities.get(p["activity"])
+	if !ok || activity.Issue != p["issue"] {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, activity)
+}
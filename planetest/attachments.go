@@ -0,0 +1,165 @@
+package planetest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/GeekWorkCode/plane-api-go/models"
+)
+
+// uploadRequest is the body of the get-upload-url endpoint
+type uploadRequest struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Size int64  `json:"size"`
+}
+
+func (s *Server) listAttachments(w http.ResponseWriter, r *http.Request, p params) {
+	if !s.checkScope(w, p) {
+		return
+	}
+	attachments := s.attachments.filter(func(a *models.Attachment) bool {
+		return a.Issue == p["issue"] && a.IsUploaded
+	})
+	writeJSON(w, http.StatusOK, attachments)
+}
+
+func (s *Server) createUploadURL(w http.ResponseWriter, r *http.Request, p params) {
+	if !s.checkScope(w, p) {
+		return
+	}
+	var req uploadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "JSON parse error")
+		return
+	}
+	if req.Name == "" {
+		writeJSON(w, http.StatusBadRequest, required("name"))
+		return
+	}
+
+	id := newID()
+	asset := p["workspace"] + "/" + id + "-" + req.Name
+	attachment := &models.Attachment{
+		ID:        id,
+		CreatedAt: s.now(),
+		UpdatedAt: s.now(),
+		Attributes: map[string]interface{}{
+			"name": req.Name,
+			"type": req.Type,
+			"size": req.Size,
+		},
+		Asset:      asset,
+		EntityType: "ISSUE_ATTACHMENT",
+		Size:       float64(req.Size),
+		Workspace:  p["workspace"],
+		Project:    p["project"],
+		Issue:      p["issue"],
+	}
+	s.attachments.put(id, attachment)
+
+	writeJSON(w, http.StatusOK, models.UploadCredentials{
+		UploadData: models.S3UploadData{
+			URL: s.URL + "/_uploads/",
+			Fields: map[string]string{
+				"key":          asset,
+				"Content-Type": req.Type,
+			},
+		},
+		AssetID:    id,
+		Attachment: *attachment,
+		AssetURL:   s.URL + "/_uploads/" + asset,
+	})
+}
+
+func (s *Server) attachment(w http.ResponseWriter, p params) (*models.Attachment, bool) {
+	if !s.checkScope(w, p) {
+		return nil, false
+	}
+	attachment, ok := s.attachments.get(p["attachment"])
+	if !ok || attachment.Issue != p["issue"] {
+		writeNotFound(w)
+		return nil, false
+	}
+	return attachment, true
+}
+
+// getAttachment redirects to the uploaded file, as Plane redirects to a presigned storage URL
+func (s *Server) getAttachment(w http.ResponseWriter, r *http.Request, p params) {
+	attachment, ok := s.attachment(w, p)
+	if !ok {
+		return
+	}
+	if !attachment.IsUploaded {
+		writeError(w, http.StatusBadRequest, "The asset is not uploaded.")
+		return
+	}
+	http.Redirect(w, r, s.URL+"/_uploads/"+attachment.Asset, http.StatusFound)
+}
+
+func (s *Server) completeUpload(w http.ResponseWriter, r *http.Request, p params) {
+	attachment, ok := s.attachment(w, p)
+	if !ok {
+		return
+	}
+	attachment.IsUploaded = true
+	attachment.UpdatedAt = s.now()
+	writeJSON(w, http.StatusOK, attachment)
+}
+
+func (s *Server) deleteAttachment(w http.ResponseWriter, r *http.Request, p params) {
+	if _, ok := s.attachment(w, p); !ok {
+		return
+	}
+	s.attachments.delete(p["attachment"])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleUpload fakes the storage endpoint files are posted to with the
+// fields returned by get-upload-url, and downloaded from
+func (s *Server) handleUpload(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		s.mu.Lock()
+		content, ok := s.uploads[strings.TrimPrefix(r.URL.Path, "/_uploads/")]
+		s.mu.Unlock()
+		if !ok {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		w.Write(content)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		http.Error(w, "invalid multipart form", http.StatusBadRequest)
+		return
+	}
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "missing file", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	if err != nil {
+		http.Error(w, "invalid file", http.StatusBadRequest)
+		return
+	}
+
+	key := r.FormValue("key")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, attachment := range s.attachments.items {
+		if attachment.Asset == key && strings.TrimSpace(key) != "" {
+			s.uploads[key] = content
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	http.Error(w, "invalid key", http.StatusForbidden)
+}
@@ -0,0 +1,164 @@
+package planetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// resource describes a CRUD collection of the fake API
+type resource[T any] struct {
+	table *table[T]
+	// key is the name of the path parameter holding the item ID
+	key string
+	// id returns the ID of an item
+	id func(item *T) string
+	// scope reports whether an item belongs to the collection addressed by p
+	scope func(item *T, p params) bool
+	// create validates a new item and fills its server side fields
+	create func(item *T, p params, fields map[string]json.RawMessage) error
+	// update refreshes server side fields after an update, may be nil
+	update func(item *T, fields map[string]json.RawMessage)
+	// paginated selects the paginated list response instead of a plain array
+	paginated bool
+	// list writes the list response, may be nil to write items as they are
+	list func(w http.ResponseWriter, r *http.Request, items []T)
+}
+
+// validationError is returned by resource callbacks to answer with a 400
+type validationError map[string][]string
+
+func (e validationError) Error() string {
+	return fmt.Sprint(map[string][]string(e))
+}
+
+func required(field string) validationError {
+	return validationError{field: {"This field is required."}}
+}
+
+// register adds the list, create, get, update and delete endpoints of a resource
+func register[T any](s *Server, collection string, item string, res resource[T]) {
+	s.handle(http.MethodGet, collection, func(w http.ResponseWriter, r *http.Request, p params) {
+		if !s.checkScope(w, p) {
+			return
+		}
+		items := res.table.filter(func(it *T) bool { return res.scope(it, p) })
+		if res.list != nil {
+			res.list(w, r, items)
+			return
+		}
+		if res.paginated {
+			writePage(s, w, r, items)
+			return
+		}
+		writeJSON(w, http.StatusOK, items)
+	})
+
+	s.handle(http.MethodPost, collection, func(w http.ResponseWriter, r *http.Request, p params) {
+		if !s.checkScope(w, p) {
+			return
+		}
+		fields, err := readBody(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		it := new(T)
+		if err := apply(it, fields); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err := res.create(it, p, fields); err != nil {
+			writeValidationError(w, err)
+			return
+		}
+		res.table.put(res.id(it), it)
+		writeJSON(w, http.StatusCreated, it)
+	})
+
+	s.handle(http.MethodGet, item, func(w http.ResponseWriter, r *http.Request, p params) {
+		if it, ok := lookup(s, w, res, p); ok {
+			writeJSON(w, http.StatusOK, it)
+		}
+	})
+
+	s.handle(http.MethodPatch, item, func(w http.ResponseWriter, r *http.Request, p params) {
+		it, ok := lookup(s, w, res, p)
+		if !ok {
+			return
+		}
+		fields, err := readBody(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		id := res.id(it)
+		updated := *it
+		if err := apply(&updated, withoutReadOnly(fields)); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if res.update != nil {
+			res.update(&updated, fields)
+		}
+		res.table.put(id, &updated)
+		writeJSON(w, http.StatusOK, &updated)
+	})
+
+	s.handle(http.MethodDelete, item, func(w http.ResponseWriter, r *http.Request, p params) {
+		it, ok := lookup(s, w, res, p)
+		if !ok {
+			return
+		}
+		res.table.delete(res.id(it))
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// withoutReadOnly drops fields clients cannot change
+func withoutReadOnly(fields map[string]json.RawMessage) map[string]json.RawMessage {
+	for _, key := range []string{"id", "project", "workspace", "created_at", "created_by", "issue", "sequence_id"} {
+		delete(fields, key)
+	}
+	return fields
+}
+
+func writeValidationError(w http.ResponseWriter, err error) {
+	if v, ok := err.(validationError); ok {
+		writeJSON(w, http.StatusBadRequest, v)
+		return
+	}
+	writeError(w, http.StatusBadRequest, err.Error())
+}
+
+// lookup finds the item addressed by the path, writing a 404 if it does not exist
+func lookup[T any](s *Server, w http.ResponseWriter, res resource[T], p params) (*T, bool) {
+	if !s.checkScope(w, p) {
+		return nil, false
+	}
+	it, ok := res.table.get(p[res.key])
+	if !ok || !res.scope(it, p) {
+		writeNotFound(w)
+		return nil, false
+	}
+	return it, true
+}
+
+// checkScope verifies that the workspace, project and issue in the path exist
+func (s *Server) checkScope(w http.ResponseWriter, p params) bool {
+	if projectID, ok := p["project"]; ok {
+		project, found := s.projects.get(projectID)
+		if !found || project.Workspace != p["workspace"] {
+			writeNotFound(w)
+			return false
+		}
+	}
+	if issueID, ok := p["issue"]; ok {
+		issue, found := s.issues.get(issueID)
+		if !found || issue.Project != p["project"] {
+			writeNotFound(w)
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,349 @@
+package planetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GeekWorkCode/plane-api-go/models"
+)
+
+// priorityRank orders priorities from most to least urgent
+var priorityRank = map[string]int{"urgent": 0, "high": 1, "medium": 2, "low": 3, "none": 4}
+
+// issueOrder compares two issues by a field for the order_by parameter
+var issueOrder = map[string]func(a, b *models.Issue) bool{
+	"created_at":  func(a, b *models.Issue) bool { return a.CreatedAt.Before(b.CreatedAt) },
+	"updated_at":  func(a, b *models.Issue) bool { return a.UpdatedAt.Before(b.UpdatedAt) },
+	"sequence_id": func(a, b *models.Issue) bool { return a.SequenceID < b.SequenceID },
+	"sort_order":  func(a, b *models.Issue) bool { return a.SortOrder < b.SortOrder },
+	"name":        func(a, b *models.Issue) bool { return a.Name < b.Name },
+	"priority":    func(a, b *models.Issue) bool { return priorityRank[a.Priority] < priorityRank[b.Priority] },
+	"start_date":  func(a, b *models.Issue) bool { return deref(a.StartDate) < deref(b.StartDate) },
+	"target_date": func(a, b *models.Issue) bool { return deref(a.TargetDate) < deref(b.TargetDate) },
+}
+
+// listIssues writes a page of the live issues of a project. Like Plane, it
+// leaves out archived and draft issues.
+func (s *Server) listIssues(w http.ResponseWriter, r *http.Request, items []models.Issue) {
+	live := []models.Issue{}
+	for _, it := range items {
+		if it.ArchivedAt == nil && !it.IsDraft {
+			live = append(live, it)
+		}
+	}
+	s.writeIssues(w, r, live)
+}
+
+// listArchivedIssues writes a page of the archived issues of a project
+func (s *Server) listArchivedIssues(w http.ResponseWriter, r *http.Request, p params) {
+	if !s.checkScope(w, p) {
+		return
+	}
+	s.writeIssues(w, r, s.issues.filter(func(it *models.Issue) bool {
+		return it.Project == p["project"] && it.ArchivedAt != nil
+	}))
+}
+
+// archiveIssue archives an issue. Plane only archives issues in a completed or cancelled state.
+func (s *Server) archiveIssue(w http.ResponseWriter, r *http.Request, p params) {
+	if !s.checkScope(w, p) {
+		return
+	}
+	it, _ := s.issues.get(p["issue"])
+	state, ok := s.states.get(it.State)
+	if !ok || (state.Group != models.StateGroupCompleted && state.Group != models.StateGroupCancelled) {
+		writeError(w, http.StatusBadRequest, "Can only archive completed or cancelled state group issue")
+		return
+	}
+	now := s.now()
+	it.ArchivedAt = &now
+	it.UpdatedAt = now
+	archivedAt := now.Format("2006-01-02")
+	s.addActivity(it, models.Activity{Verb: "updated", Field: "archived_at", NewValue: archivedAt})
+	writeJSON(w, http.StatusOK, map[string]string{"archived_at": archivedAt})
+}
+
+// unarchiveIssue restores an archived issue
+func (s *Server) unarchiveIssue(w http.ResponseWriter, r *http.Request, p params) {
+	if !s.checkScope(w, p) {
+		return
+	}
+	it, _ := s.issues.get(p["issue"])
+	if it.ArchivedAt != nil {
+		it.ArchivedAt = nil
+		it.UpdatedAt = s.now()
+		s.addActivity(it, models.Activity{Verb: "updated", Field: "archived_at", NewValue: "restore"})
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeIssues writes a page of issues, applying the filters, order_by, expand
+// and fields parameters of the query
+func (s *Server) writeIssues(w http.ResponseWriter, r *http.Request, items []models.Issue) {
+	query := r.URL.Query()
+
+	keep, err := s.issueFilter(query)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	issues := []models.Issue{}
+	for i := range items {
+		if keep(&items[i]) {
+			issues = append(issues, items[i])
+		}
+	}
+
+	if orderBy := query.Get("order_by"); orderBy != "" {
+		less, ok := issueOrder[strings.TrimPrefix(orderBy, "-")]
+		if !ok {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid order_by field: %s", orderBy))
+			return
+		}
+		desc := strings.HasPrefix(orderBy, "-")
+		sort.SliceStable(issues, func(i, j int) bool {
+			if desc {
+				return less(&issues[j], &issues[i])
+			}
+			return less(&issues[i], &issues[j])
+		})
+	}
+
+	expand, fields := splitList(query.Get("expand")), splitList(query.Get("fields"))
+	if len(expand) == 0 && len(fields) == 0 {
+		writePage(s, w, r, issues)
+		return
+	}
+	results := make([]map[string]interface{}, 0, len(issues))
+	for i := range issues {
+		results = append(results, s.renderIssue(&issues[i], expand, fields))
+	}
+	writePage(s, w, r, results)
+}
+
+// issueFilter builds the filter selected by the query parameters
+func (s *Server) issueFilter(query url.Values) (func(*models.Issue) bool, error) {
+	var filters []func(*models.Issue) bool
+	if v := splitList(query.Get("state")); len(v) > 0 {
+		filters = append(filters, func(it *models.Issue) bool { return contains(v, it.State) })
+	}
+	if v := splitList(query.Get("state_group")); len(v) > 0 {
+		filters = append(filters, func(it *models.Issue) bool {
+			state, ok := s.states.get(it.State)
+			return ok && contains(v, state.Group)
+		})
+	}
+	if v := splitList(query.Get("priority")); len(v) > 0 {
+		filters = append(filters, func(it *models.Issue) bool { return contains(v, it.Priority) })
+	}
+	if v := splitList(query.Get("created_by")); len(v) > 0 {
+		filters = append(filters, func(it *models.Issue) bool { return contains(v, it.CreatedBy) })
+	}
+	if v := splitList(query.Get("parent")); len(v) > 0 {
+		filters = append(filters, func(it *models.Issue) bool { return it.Parent != nil && contains(v, *it.Parent) })
+	}
+	if v := splitList(query.Get("assignees")); len(v) > 0 {
+		filters = append(filters, func(it *models.Issue) bool { return overlaps(v, it.Assignees) })
+	}
+	if v := splitList(query.Get("labels")); len(v) > 0 {
+		filters = append(filters, func(it *models.Issue) bool { return overlaps(v, it.Labels) })
+	}
+
+	dates := map[string]func(*models.Issue) string{
+		"start_date":   func(it *models.Issue) string { return deref(it.StartDate) },
+		"target_date":  func(it *models.Issue) string { return deref(it.TargetDate) },
+		"created_at":   func(it *models.Issue) string { return formatDate(&it.CreatedAt) },
+		"updated_at":   func(it *models.Issue) string { return formatDate(&it.UpdatedAt) },
+		"completed_at": func(it *models.Issue) string { return formatDate(it.CompletedAt) },
+	}
+	for key, date := range dates {
+		raw := query.Get(key)
+		if raw == "" {
+			continue
+		}
+		inRange, err := parseDateFilter(key, raw)
+		if err != nil {
+			return nil, err
+		}
+		date := date
+		filters = append(filters, func(it *models.Issue) bool { return inRange(date(it)) })
+	}
+
+	return func(it *models.Issue) bool {
+		for _, keep := range filters {
+			if !keep(it) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// parseDateFilter parses Plane's date filter format "2024-01-01;after,2024-01-31;before"
+func parseDateFilter(key string, raw string) (func(date string) bool, error) {
+	var after, before string
+	for _, term := range strings.Split(raw, ",") {
+		parts := strings.Split(term, ";")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("Invalid %s filter: %s", key, raw)
+		}
+		if _, err := time.Parse("2006-01-02", parts[0]); err != nil {
+			return nil, fmt.Errorf("Invalid %s filter: %s", key, raw)
+		}
+		switch parts[1] {
+		case "after":
+			after = parts[0]
+		case "before":
+			before = parts[0]
+		default:
+			return nil, fmt.Errorf("Invalid %s filter: %s", key, raw)
+		}
+	}
+	return func(date string) bool {
+		if date == "" {
+			return false
+		}
+		return (after == "" || date >= after) && (before == "" || date <= before)
+	}, nil
+}
+
+// renderIssue encodes an issue with the expanded fields replaced by objects,
+// keeping only the selected fields if any
+func (s *Server) renderIssue(it *models.Issue, expand []string, fields []string) map[string]interface{} {
+	data, _ := json.Marshal(it)
+	out := map[string]interface{}{}
+	json.Unmarshal(data, &out)
+
+	if contains(expand, "state") {
+		if state, ok := s.states.get(it.State); ok {
+			out["state"] = state
+		}
+	}
+	if contains(expand, "assignees") {
+		assignees := []models.MemberUser{}
+		for _, id := range it.Assignees {
+			if m := s.member(it.Project, id); m != nil {
+				assignees = append(assignees, *m)
+			}
+		}
+		out["assignees"] = assignees
+	}
+	if contains(expand, "labels") {
+		labels := []models.Label{}
+		for _, id := range it.Labels {
+			if label, ok := s.labels.get(id); ok {
+				labels = append(labels, *label)
+			}
+		}
+		out["labels"] = labels
+	}
+
+	if len(fields) > 0 {
+		for key := range out {
+			if key != "id" && !contains(fields, key) {
+				delete(out, key)
+			}
+		}
+	}
+	return out
+}
+
+// splitList splits a comma separated query parameter
+func splitList(v string) []string {
+	if v == "" {
+		return nil
+	}
+	return strings.Split(v, ",")
+}
+
+// overlaps reports whether any of ids is in values
+func overlaps(ids []string, values []string) bool {
+	for _, v := range values {
+		if contains(ids, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// deref returns the value of an optional string, or "" if it is not set
+func deref(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+// sequenceNumbers finds the whole numbers in a search query
+var sequenceNumbers = regexp.MustCompile(`\b\d+\b`)
+
+// searchIssues implements the workspace issue search: the query matches names and
+// project identifiers containing it and the sequence numbers of the numbers in it
+func (s *Server) searchIssues(w http.ResponseWriter, r *http.Request, p params) {
+	query := r.URL.Query()
+	search := strings.ToLower(query.Get("search"))
+	limit := 10
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid limit.")
+			return
+		}
+		limit = n
+	}
+	projectID := ""
+	if query.Get("workspace_search") != "true" {
+		projectID = query.Get("project_id")
+	}
+
+	sequences := map[int]bool{}
+	for _, match := range sequenceNumbers.FindAllString(search, -1) {
+		n, _ := strconv.Atoi(match)
+		sequences[n] = true
+	}
+	results := []models.IssueSearchResult{}
+	if search == "" {
+		writeJSON(w, http.StatusOK, models.IssueSearchResponse{Issues: results})
+		return
+	}
+	for _, it := range s.issues.filter(func(it *models.Issue) bool {
+		return it.Workspace == p["workspace"] && it.ArchivedAt == nil && !it.IsDraft &&
+			(projectID == "" || it.Project == projectID)
+	}) {
+		project, ok := s.projects.get(it.Project)
+		if !ok {
+			continue
+		}
+		if !strings.Contains(strings.ToLower(it.Name), search) &&
+			!strings.Contains(strings.ToLower(project.Identifier), search) &&
+			!sequences[it.SequenceID] {
+			continue
+		}
+		if len(results) == limit {
+			break
+		}
+		results = append(results, models.IssueSearchResult{
+			ID:                it.ID,
+			Name:              it.Name,
+			SequenceID:        it.SequenceID,
+			ProjectIdentifier: project.Identifier,
+			ProjectID:         project.ID,
+			WorkspaceSlug:     it.Workspace,
+		})
+	}
+	writeJSON(w, http.StatusOK, models.IssueSearchResponse{Issues: results})
+}
@@ -0,0 +1,160 @@
+package planetest
+
+import (
+	"net/http"
+
+	"github.com/GeekWorkCode/plane-api-go/models"
+)
+
+// Relations are stored the way Plane stores them: "A blocks B" is kept as
+// B blocked_by A, and the other relation types are symmetric.
+
+// relatedTo returns the issue related to id by a stored relation, and whether
+// id is the issue side of the relation. ok is false if id is not part of it.
+func relatedTo(rel *models.IssueRelation, id string) (related string, isIssue bool, ok bool) {
+	switch id {
+	case rel.Issue:
+		return rel.RelatedIssue, true, true
+	case rel.RelatedIssue:
+		return rel.Issue, false, true
+	}
+	return "", false, false
+}
+
+func (s *Server) listRelations(w http.ResponseWriter, r *http.Request, p params) {
+	if !s.checkScope(w, p) {
+		return
+	}
+	id := p["issue"]
+	out := models.IssueRelations{
+		Blocking:  []models.RelatedIssue{},
+		BlockedBy: []models.RelatedIssue{},
+		Duplicate: []models.RelatedIssue{},
+		RelatesTo: []models.RelatedIssue{},
+	}
+	for _, rel := range s.relations.filter(func(*models.IssueRelation) bool { return true }) {
+		relatedID, isIssue, ok := relatedTo(&rel, id)
+		if !ok {
+			continue
+		}
+		related, found := s.issues.get(relatedID)
+		if !found {
+			continue
+		}
+		item := models.RelatedIssue{
+			ID:          related.ID,
+			Name:        related.Name,
+			SequenceID:  related.SequenceID,
+			ProjectID:   related.Project,
+			StateID:     related.State,
+			Priority:    related.Priority,
+			AssigneeIDs: related.Assignees,
+		}
+		switch {
+		case rel.RelationType == models.RelationBlockedBy && isIssue:
+			item.RelationType = models.RelationBlockedBy
+			out.BlockedBy = append(out.BlockedBy, item)
+		case rel.RelationType == models.RelationBlockedBy:
+			item.RelationType = models.RelationBlocking
+			out.Blocking = append(out.Blocking, item)
+		case rel.RelationType == models.RelationDuplicate:
+			item.RelationType = models.RelationDuplicate
+			out.Duplicate = append(out.Duplicate, item)
+		case rel.RelationType == models.RelationRelatesTo:
+			item.RelationType = models.RelationRelatesTo
+			out.RelatesTo = append(out.RelatesTo, item)
+		}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) createRelations(w http.ResponseWriter, r *http.Request, p params) {
+	if !s.checkScope(w, p) {
+		return
+	}
+	fields, err := readBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	relationType := stringField(fields, "relation_type")
+	switch relationType {
+	case models.RelationBlocking, models.RelationBlockedBy, models.RelationDuplicate, models.RelationRelatesTo:
+	default:
+		writeValidationError(w, validationError{"relation_type": {"Invalid relation type."}})
+		return
+	}
+	ids := stringsField(fields, "issues")
+	if len(ids) == 0 {
+		writeValidationError(w, required("issues"))
+		return
+	}
+	for _, id := range ids {
+		related, ok := s.issues.get(id)
+		if !ok || related.Workspace != p["workspace"] || id == p["issue"] {
+			writeValidationError(w, validationError{"issues": {"Invalid issue."}})
+			return
+		}
+	}
+
+	created := []models.IssueRelation{}
+	for _, id := range ids {
+		rel := models.IssueRelation{
+			ID:           newID(),
+			Issue:        p["issue"],
+			RelatedIssue: id,
+			RelationType: relationType,
+			Project:      p["project"],
+			Workspace:    p["workspace"],
+			CreatedAt:    s.now(),
+			UpdatedAt:    s.now(),
+		}
+		if relationType == models.RelationBlocking {
+			rel.Issue, rel.RelatedIssue, rel.RelationType = id, p["issue"], models.RelationBlockedBy
+		}
+		if existing := s.findRelation(rel.Issue, rel.RelatedIssue, rel.RelationType); existing != nil {
+			created = append(created, *existing)
+			continue
+		}
+		s.relations.put(rel.ID, &rel)
+		created = append(created, rel)
+	}
+	writeJSON(w, http.StatusCreated, created)
+}
+
+func (s *Server) removeRelation(w http.ResponseWriter, r *http.Request, p params) {
+	if !s.checkScope(w, p) {
+		return
+	}
+	fields, err := readBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	issue, related := p["issue"], stringField(fields, "related_issue")
+	relationType := stringField(fields, "relation_type")
+	if relationType == models.RelationBlocking {
+		issue, related, relationType = related, issue, models.RelationBlockedBy
+	}
+	if rel := s.findRelation(issue, related, relationType); rel != nil {
+		s.relations.delete(rel.ID)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// findRelation finds a stored relation. The blocked_by relation is directed,
+// the other relation types match in both directions.
+func (s *Server) findRelation(issue string, related string, relationType string) *models.IssueRelation {
+	for _, rel := range s.relations.items {
+		if rel.RelationType != relationType {
+			continue
+		}
+		if rel.Issue == issue && rel.RelatedIssue == related {
+			return rel
+		}
+		if relationType != models.RelationBlockedBy && rel.Issue == related && rel.RelatedIssue == issue {
+			return rel
+		}
+	}
+	return nil
+}
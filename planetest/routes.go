@@ -0,0 +1,463 @@
+package planetest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/GeekWorkCode/plane-api-go/models"
+)
+
+const (
+	projectsPath = "workspaces/:workspace/projects"
+	projectPath  = projectsPath + "/:project"
+	issuesPath   = projectPath + "/issues"
+	issuePath    = issuesPath + "/:issue"
+)
+
+// defaultStates are the states Plane creates for every new project
+var defaultStates = []models.State{
+	{Name: "Backlog", Color: "#A3A3A3", Group: models.StateGroupBacklog},
+	{Name: "Todo", Color: "#3A3A3A", Group: models.StateGroupUnstarted},
+	{Name: "In Progress", Color: "#F59E0B", Group: models.StateGroupStarted},
+	{Name: "Done", Color: "#16A34A", Group: models.StateGroupCompleted},
+	{Name: "Cancelled", Color: "#EF4444", Group: models.StateGroupCancelled},
+}
+
+func inProject(project string, p params) bool { return project == p["project"] }
+
+func (s *Server) registerRoutes() {
+	register(s, projectsPath, projectPath, resource[models.Project]{
+		table: s.projects,
+		key:   "project",
+		id:    func(it *models.Project) string { return it.ID },
+		scope: func(it *models.Project, p params) bool { return it.Workspace == p["workspace"] },
+		create: func(it *models.Project, p params, fields map[string]json.RawMessage) error {
+			if it.Name == "" {
+				return required("name")
+			}
+			if it.Identifier == "" {
+				return required("identifier")
+			}
+			it.Identifier = strings.ToUpper(it.Identifier)
+			for _, other := range s.projects.items {
+				if other.Workspace == p["workspace"] && other.Identifier == it.Identifier {
+					return validationError{"identifier": {"The project identifier is already taken"}}
+				}
+			}
+			s.initProject(it, p["workspace"])
+			return nil
+		},
+		update:    func(it *models.Project, _ map[string]json.RawMessage) { it.UpdatedAt = s.now() },
+		paginated: true,
+	})
+
+	register(s, issuesPath, issuePath, resource[models.Issue]{
+		table: s.issues,
+		key:   "issue",
+		id:    func(it *models.Issue) string { return it.ID },
+		scope: func(it *models.Issue, p params) bool { return inProject(it.Project, p) },
+		create: func(it *models.Issue, p params, fields map[string]json.RawMessage) error {
+			if it.Name == "" {
+				return required("name")
+			}
+			if it.Parent != nil {
+				if parent, ok := s.issues.get(*it.Parent); !ok || parent.Project != p["project"] {
+					return validationError{"parent": {"Invalid parent issue."}}
+				}
+			}
+			s.initIssue(it, p["workspace"], p["project"])
+			return nil
+		},
+		update: func(it *models.Issue, _ map[string]json.RawMessage) {
+			// 表中仍是更新前的问题
+			old, _ := s.issues.get(it.ID)
+			s.issueUpdated(old, it)
+		},
+		paginated: true,
+		list:      s.listIssues,
+	})
+
+	s.handle(http.MethodPost, issuePath+"/archive", s.archiveIssue)
+	s.handle(http.MethodDelete, issuePath+"/archive", s.unarchiveIssue)
+	s.handle(http.MethodGet, projectPath+"/archived-issues", s.listArchivedIssues)
+	s.handle(http.MethodGet, "workspaces/:workspace/issues/search", s.searchIssues)
+	s.handle(http.MethodGet, "workspaces/:workspace/issues/:sequence", s.getIssueBySequence)
+	s.handle(http.MethodPatch, "workspaces/:workspace/issues/:sequence", s.updateIssueBySequence)
+
+	register(s, projectPath+"/states", projectPath+"/states/:state", resource[models.State]{
+		table: s.states,
+		key:   "state",
+		id:    func(it *models.State) string { return it.ID },
+		scope: func(it *models.State, p params) bool { return inProject(it.Project, p) },
+		create: func(it *models.State, p params, fields map[string]json.RawMessage) error {
+			if it.Name == "" {
+				return required("name")
+			}
+			if it.Color == "" {
+				return required("color")
+			}
+			it.ID, it.Project, it.Workspace = newID(), p["project"], p["workspace"]
+			it.CreatedAt, it.UpdatedAt = s.now(), s.now()
+			return nil
+		},
+		update:    func(it *models.State, _ map[string]json.RawMessage) { it.UpdatedAt = s.now() },
+		paginated: true,
+	})
+
+	register(s, projectPath+"/labels", projectPath+"/labels/:label", resource[models.Label]{
+		table: s.labels,
+		key:   "label",
+		id:    func(it *models.Label) string { return it.ID },
+		scope: func(it *models.Label, p params) bool { return inProject(it.Project, p) },
+		create: func(it *models.Label, p params, fields map[string]json.RawMessage) error {
+			if it.Name == "" {
+				return required("name")
+			}
+			it.ID, it.Project, it.Workspace = newID(), p["project"], p["workspace"]
+			it.CreatedAt, it.UpdatedAt = s.now(), s.now()
+			return nil
+		},
+		update:    func(it *models.Label, _ map[string]json.RawMessage) { it.UpdatedAt = s.now() },
+		paginated: true,
+	})
+
+	register(s, projectPath+"/cycles", projectPath+"/cycles/:cycle", resource[models.Cycle]{
+		table: s.cycles,
+		key:   "cycle",
+		id:    func(it *models.Cycle) string { return it.ID },
+		scope: func(it *models.Cycle, p params) bool { return inProject(it.Project, p) },
+		create: func(it *models.Cycle, p params, fields map[string]json.RawMessage) error {
+			if it.Name == "" {
+				return required("name")
+			}
+			it.ID, it.Project, it.Workspace = newID(), p["project"], p["workspace"]
+			it.CreatedAt, it.UpdatedAt = s.now(), s.now()
+			return nil
+		},
+		update:    func(it *models.Cycle, _ map[string]json.RawMessage) { it.UpdatedAt = s.now() },
+		paginated: true,
+	})
+
+	register(s, projectPath+"/modules", projectPath+"/modules/:module", resource[models.Module]{
+		table: s.modules,
+		key:   "module",
+		id:    func(it *models.Module) string { return it.ID },
+		scope: func(it *models.Module, p params) bool { return inProject(it.Project, p) },
+		create: func(it *models.Module, p params, fields map[string]json.RawMessage) error {
+			if it.Name == "" {
+				return required("name")
+			}
+			it.ID, it.Project, it.Workspace = newID(), p["project"], p["workspace"]
+			it.CreatedAt, it.UpdatedAt = s.now(), s.now()
+			return nil
+		},
+		update:    func(it *models.Module, _ map[string]json.RawMessage) { it.UpdatedAt = s.now() },
+		paginated: true,
+	})
+
+	s.registerMembership(projectPath+"/cycles/:cycle/cycle-issues", "cycle", s.cycleIssues, func(id string) bool {
+		_, ok := s.cycles.get(id)
+		return ok
+	})
+	s.registerMembership(projectPath+"/modules/:module/module-issues", "module", s.moduleIssues, func(id string) bool {
+		_, ok := s.modules.get(id)
+		return ok
+	})
+
+	register(s, issuePath+"/comments", issuePath+"/comments/:comment", resource[models.Comment]{
+		table: s.comments,
+		key:   "comment",
+		id:    func(it *models.Comment) string { return it.ID },
+		scope: func(it *models.Comment, p params) bool { return it.Issue == p["issue"] },
+		create: func(it *models.Comment, p params, fields map[string]json.RawMessage) error {
+			if it.CommentHTML == "" {
+				return required("comment_html")
+			}
+			it.ID, it.Project, it.Workspace, it.Issue = newID(), p["project"], p["workspace"], p["issue"]
+			it.CreatedAt, it.UpdatedAt = s.now(), s.now()
+			author := stringField(fields, "created_by")
+			if author == "" {
+				author = stringField(fields, "actor")
+			}
+			it.CreatedBy, it.UpdatedBy = author, author
+			it.Member = s.member(it.Project, author)
+			return nil
+		},
+		update: func(it *models.Comment, fields map[string]json.RawMessage) {
+			it.UpdatedAt = s.now()
+			if actor := stringField(fields, "actor"); actor != "" {
+				it.UpdatedBy = actor
+				it.Member = s.member(it.Project, actor)
+			}
+		},
+		paginated: true,
+	})
+
+	register(s, issuePath+"/links", issuePath+"/links/:link", resource[models.Link]{
+		table: s.links,
+		key:   "link",
+		id:    func(it *models.Link) string { return it.ID },
+		scope: func(it *models.Link, p params) bool { return it.Issue == p["issue"] },
+		create: func(it *models.Link, p params, fields map[string]json.RawMessage) error {
+			if it.URL == "" {
+				return required("url")
+			}
+			for _, other := range s.links.items {
+				if other.Issue == p["issue"] && other.URL == it.URL {
+					return validationError{"error": {"URL already exists for this Issue"}}
+				}
+			}
+			it.ID, it.Project, it.Workspace, it.Issue = newID(), p["project"], p["workspace"], p["issue"]
+			it.CreatedAt, it.UpdatedAt = s.now(), s.now()
+			return nil
+		},
+		update:    func(it *models.Link, _ map[string]json.RawMessage) { it.UpdatedAt = s.now() },
+		paginated: true,
+	})
+
+	register(s, issuePath+"/worklogs", issuePath+"/worklogs/:worklog", resource[models.Worklog]{
+		table: s.worklogs,
+		key:   "worklog",
+		id:    func(it *models.Worklog) string { return it.ID },
+		scope: func(it *models.Worklog, p params) bool { return s.worklogIssue[it.ID] == p["issue"] },
+		create: func(it *models.Worklog, p params, fields map[string]json.RawMessage) error {
+			if it.Duration <= 0 {
+				return validationError{"duration": {"Ensure this value is greater than 0."}}
+			}
+			it.ID, it.ProjectID, it.WorkspaceID = newID(), p["project"], p["workspace"]
+			it.CreatedAt, it.UpdatedAt = s.now(), s.now()
+			s.worklogIssue[it.ID] = p["issue"]
+			return nil
+		},
+		update: func(it *models.Worklog, _ map[string]json.RawMessage) { it.UpdatedAt = s.now() },
+	})
+	s.handle(http.MethodGet, projectPath+"/total-worklogs", s.totalWorklogs)
+
+	s.handle(http.MethodGet, issuePath+"/activities", s.listActivities)
+	s.handle(http.MethodGet, issuePath+"/activities/:activity", s.getActivity)
+
+	s.handle(http.MethodGet, issuePath+"/issue-relation", s.listRelations)
+	s.handle(http.MethodPost, issuePath+"/issue-relation", s.createRelations)
+	s.handle(http.MethodPost, issuePath+"/remove-relation", s.removeRelation)
+
+	s.handle(http.MethodGet, projectPath+"/members", s.listMembers)
+
+	s.handle(http.MethodGet, issuePath+"/issue-attachments", s.listAttachments)
+	s.handle(http.MethodPost, issuePath+"/issue-attachments/get-upload-url", s.createUploadURL)
+	s.handle(http.MethodGet, issuePath+"/issue-attachments/:attachment", s.getAttachment)
+	s.handle(http.MethodPatch, issuePath+"/issue-attachments/:attachment", s.completeUpload)
+	s.handle(http.MethodDelete, issuePath+"/issue-attachments/:attachment", s.deleteAttachment)
+}
+
+// initProject fills the server side fields of a new project and creates its default states
+func (s *Server) initProject(it *models.Project, workspace string) {
+	it.ID, it.Workspace = newID(), workspace
+	it.CreatedAt, it.UpdatedAt = s.now(), s.now()
+	for _, state := range defaultStates {
+		state := state
+		state.ID, state.Project, state.Workspace = newID(), it.ID, workspace
+		state.CreatedAt, state.UpdatedAt = s.now(), s.now()
+		s.states.put(state.ID, &state)
+	}
+}
+
+// initIssue fills the server side fields of a new issue
+func (s *Server) initIssue(it *models.Issue, workspace string, project string) {
+	it.ID, it.Project, it.Workspace = newID(), project, workspace
+	it.CreatedAt, it.UpdatedAt = s.now(), s.now()
+	if it.State == "" {
+		if states := s.states.filter(func(st *models.State) bool { return st.Project == project }); len(states) > 0 {
+			it.State = states[0].ID
+		}
+	}
+	if it.Priority == "" {
+		it.Priority = "none"
+	}
+	s.lastSequence[project]++
+	it.SequenceID = s.lastSequence[project]
+	if it.CreatedBy == "" {
+		it.CreatedBy = s.UserID
+	}
+	s.addActivity(it, models.Activity{Verb: "created"})
+}
+
+// issueBySequence finds an issue by an identifier such as "WEB-12"
+func (s *Server) issueBySequence(workspace string, sequence string) (*models.Issue, bool) {
+	idx := strings.LastIndex(sequence, "-")
+	if idx < 0 {
+		return nil, false
+	}
+	number, err := strconv.Atoi(sequence[idx+1:])
+	if err != nil {
+		return nil, false
+	}
+	identifier := strings.ToUpper(sequence[:idx])
+	for _, project := range s.projects.items {
+		if project.Workspace != workspace || project.Identifier != identifier {
+			continue
+		}
+		for _, issue := range s.issues.items {
+			if issue.Project == project.ID && issue.SequenceID == number {
+				return issue, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func (s *Server) getIssueBySequence(w http.ResponseWriter, r *http.Request, p params) {
+	issue, ok := s.issueBySequence(p["workspace"], p["sequence"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, issue)
+}
+
+func (s *Server) updateIssueBySequence(w http.ResponseWriter, r *http.Request, p params) {
+	issue, ok := s.issueBySequence(p["workspace"], p["sequence"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	fields, err := readBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	updated := *issue
+	if err := apply(&updated, withoutReadOnly(fields)); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	s.issueUpdated(issue, &updated)
+	s.issues.put(updated.ID, &updated)
+	writeJSON(w, http.StatusOK, &updated)
+}
+
+// registerMembership adds the endpoints listing, adding and removing the issues of a cycle or module
+func (s *Server) registerMembership(path string, key string, membership map[string][]string, exists func(id string) bool) {
+	check := func(w http.ResponseWriter, p params) bool {
+		if !s.checkScope(w, p) {
+			return false
+		}
+		if !exists(p[key]) {
+			writeNotFound(w)
+			return false
+		}
+		return true
+	}
+
+	s.handle(http.MethodGet, path, func(w http.ResponseWriter, r *http.Request, p params) {
+		if !check(w, p) {
+			return
+		}
+		issues := []models.Issue{}
+		for _, id := range membership[p[key]] {
+			if issue, ok := s.issues.get(id); ok {
+				issues = append(issues, *issue)
+			}
+		}
+		writePage(s, w, r, issues)
+	})
+
+	s.handle(http.MethodPost, path, func(w http.ResponseWriter, r *http.Request, p params) {
+		if !check(w, p) {
+			return
+		}
+		fields, err := readBody(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		ids := stringsField(fields, "issues")
+		if len(ids) == 0 {
+			writeJSON(w, http.StatusBadRequest, validationError{"error": {"Issues are required"}})
+			return
+		}
+		for _, id := range ids {
+			issue, ok := s.issues.get(id)
+			if !ok || issue.Project != p["project"] {
+				writeNotFound(w)
+				return
+			}
+		}
+		for _, id := range ids {
+			if !contains(membership[p[key]], id) {
+				membership[p[key]] = append(membership[p[key]], id)
+			}
+		}
+		writeJSON(w, http.StatusCreated, map[string]string{"message": "success"})
+	})
+
+	s.handle(http.MethodDelete, path+"/:issue", func(w http.ResponseWriter, r *http.Request, p params) {
+		if !check(w, p) {
+			return
+		}
+		ids := membership[p[key]]
+		for i, id := range ids {
+			if id == p["issue"] {
+				membership[p[key]] = append(ids[:i], ids[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		writeNotFound(w)
+	})
+}
+
+func (s *Server) totalWorklogs(w http.ResponseWriter, r *http.Request, p params) {
+	if !s.checkScope(w, p) {
+		return
+	}
+	totals := []models.WorklogTotal{}
+	index := map[string]int{}
+	for _, id := range s.worklogs.order {
+		worklog := s.worklogs.items[id]
+		if worklog.ProjectID != p["project"] {
+			continue
+		}
+		issueID := s.worklogIssue[id]
+		i, ok := index[issueID]
+		if !ok {
+			i = len(totals)
+			index[issueID] = i
+			totals = append(totals, models.WorklogTotal{IssueID: issueID})
+		}
+		totals[i].Duration += float64(worklog.Duration)
+	}
+	writeJSON(w, http.StatusOK, totals)
+}
+
+func (s *Server) listMembers(w http.ResponseWriter, r *http.Request, p params) {
+	if !s.checkScope(w, p) {
+		return
+	}
+	members := s.members[p["project"]]
+	if members == nil {
+		members = []models.MemberUser{}
+	}
+	writeJSON(w, http.StatusOK, members)
+}
+
+// member returns the project member with the given ID, or nil
+func (s *Server) member(projectID string, memberID string) *models.MemberUser {
+	for _, m := range s.members[projectID] {
+		if m.ID == memberID {
+			member := m
+			return &member
+		}
+	}
+	return nil
+}
+
+func contains(ids []string, id string) bool {
+	for _, existing := range ids {
+		if existing == id {
+			return true
+		}
+	}
+	return false
+}
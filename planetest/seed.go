@@ -0,0 +1,119 @@
+package planetest
+
+import "github.com/GeekWorkCode/plane-api-go/models"
+
+// Fixture holds the identifiers of the data created by Seed
+type Fixture struct {
+	WorkspaceSlug string
+	ProjectID     string
+	IssueID       string
+	Members       []models.MemberUser
+}
+
+// Seed creates a workspace with a project, two members and an issue,
+// which is what the api package tests expect to find.
+func (s *Server) Seed() Fixture {
+	const workspace = "test-workspace"
+
+	project := s.AddProject(workspace, models.Project{
+		Name:       "Demo Project",
+		Identifier: "DEMO",
+	})
+	members := []models.MemberUser{
+		s.AddMember(project.ID, models.MemberUser{
+			FirstName:   "Alice",
+			LastName:    "Smith",
+			DisplayName: "alice",
+			Email:       "alice@example.com",
+		}),
+		s.AddMember(project.ID, models.MemberUser{
+			FirstName:   "Bob",
+			LastName:    "Jones",
+			DisplayName: "bob",
+			Email:       "bob@example.com",
+		}),
+	}
+	if s.UserID == "" {
+		s.UserID = members[0].ID
+	}
+	issue := s.AddIssue(workspace, project.ID, models.Issue{
+		Name:     "Seed issue",
+		Priority: "medium",
+	})
+
+	return Fixture{
+		WorkspaceSlug: workspace,
+		ProjectID:     project.ID,
+		IssueID:       issue.ID,
+		Members:       members,
+	}
+}
+
+// AddProject stores a project together with Plane's default states
+func (s *Server) AddProject(workspaceSlug string, project models.Project) models.Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.initProject(&project, workspaceSlug)
+	s.projects.put(project.ID, &project)
+	return project
+}
+
+// AddIssue stores an issue in a project, assigning it the next sequence number
+func (s *Server) AddIssue(workspaceSlug string, projectID string, issue models.Issue) models.Issue {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.initIssue(&issue, workspaceSlug, projectID)
+	s.issues.put(issue.ID, &issue)
+	return issue
+}
+
+// AddMember adds a member to a project. An ID is generated if none is set.
+func (s *Server) AddMember(projectID string, member models.MemberUser) models.MemberUser {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if member.ID == "" {
+		member.ID = newID()
+	}
+	s.members[projectID] = append(s.members[projectID], member)
+	return member
+}
+
+// AddState stores a state in a project
+func (s *Server) AddState(workspaceSlug string, projectID string, state models.State) models.State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state.ID, state.Project, state.Workspace = newID(), projectID, workspaceSlug
+	state.CreatedAt, state.UpdatedAt = s.now(), s.now()
+	s.states.put(state.ID, &state)
+	return state
+}
+
+// AddLabel stores a label in a project
+func (s *Server) AddLabel(workspaceSlug string, projectID string, label models.Label) models.Label {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	label.ID, label.Project, label.Workspace = newID(), projectID, workspaceSlug
+	label.CreatedAt, label.UpdatedAt = s.now(), s.now()
+	s.labels.put(label.ID, &label)
+	return label
+}
+
+// AddCycle stores a cycle in a project
+func (s *Server) AddCycle(workspaceSlug string, projectID string, cycle models.Cycle) models.Cycle {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cycle.ID, cycle.Project, cycle.Workspace = newID(), projectID, workspaceSlug
+	cycle.CreatedAt, cycle.UpdatedAt = s.now(), s.now()
+	s.cycles.put(cycle.ID, &cycle)
+	return cycle
+}
+
+// AddModule stores a module in a project
+func (s *Server) AddModule(workspaceSlug string, projectID string, module models.Module) models.Module {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	module.ID, module.Project, module.Workspace = newID(), projectID, workspaceSlug
+	module.CreatedAt, module.UpdatedAt = s.now(), s.now()
+	s.modules.put(module.ID, &module)
+	return module
+}
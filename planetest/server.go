@@ -0,0 +1,286 @@
+// Package planetest provides an in-memory fake of the Plane API for tests.
+//
+// The fake implements the endpoints used by the api package with realistic
+// cursor pagination and error responses, so a client can be pointed at it
+// instead of a live workspace:
+//
+//	srv := planetest.NewServer()
+//	defer srv.Close()
+//	fx := srv.Seed()
+//
+//	p := plane.NewClient(srv.APIKey, plane.WithBaseURL(srv.BaseURL()))
+//	issues, err := p.Issues.List(fx.WorkspaceSlug, fx.ProjectID)
+package planetest
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GeekWorkCode/plane-api-go/models"
+)
+
+// DefaultAPIKey is the API key accepted by a server created with NewServer
+const DefaultAPIKey = "planetest-api-key"
+
+// DefaultPerPage is the page size used when a request does not set per_page
+const DefaultPerPage = 100
+
+// apiPrefix is the path prefix of the API, as on a self-hosted instance
+const apiPrefix = "/api/v1"
+
+// Server is an in-memory fake Plane API server
+type Server struct {
+	*httptest.Server
+
+	// APIKey is the key requests must send in the X-API-Key header.
+	// An empty key disables authentication.
+	APIKey string
+
+	// PerPage is the page size used when a request does not set per_page
+	PerPage int
+
+	// UserID is the ID of the user the API key belongs to. It is recorded as
+	// the creator of issues and the actor of activities.
+	UserID string
+
+	mu     sync.Mutex
+	routes []route
+	now    func() time.Time
+
+	projects    *table[models.Project]
+	issues      *table[models.Issue]
+	states      *table[models.State]
+	labels      *table[models.Label]
+	cycles      *table[models.Cycle]
+	modules     *table[models.Module]
+	comments    *table[models.Comment]
+	links       *table[models.Link]
+	worklogs    *table[models.Worklog]
+	attachments *table[models.Attachment]
+	relations   *table[models.IssueRelation]
+	activities  *table[models.Activity]
+
+	members      map[string][]models.MemberUser // project ID -> members
+	cycleIssues  map[string][]string            // cycle ID -> issue IDs
+	moduleIssues map[string][]string            // module ID -> issue IDs
+	worklogIssue map[string]string              // worklog ID -> issue ID
+	lastSequence map[string]int                 // project ID -> last sequence number
+	uploads      map[string][]byte              // asset key -> uploaded file content
+}
+
+// NewServer starts a fake Plane API server. Callers must Close it.
+func NewServer() *Server {
+	s := &Server{
+		APIKey:       DefaultAPIKey,
+		PerPage:      DefaultPerPage,
+		now:          func() time.Time { return time.Now().UTC() },
+		projects:     newTable[models.Project](),
+		issues:       newTable[models.Issue](),
+		states:       newTable[models.State](),
+		labels:       newTable[models.Label](),
+		cycles:       newTable[models.Cycle](),
+		modules:      newTable[models.Module](),
+		comments:     newTable[models.Comment](),
+		links:        newTable[models.Link](),
+		worklogs:     newTable[models.Worklog](),
+		attachments:  newTable[models.Attachment](),
+		relations:    newTable[models.IssueRelation](),
+		activities:   newTable[models.Activity](),
+		members:      map[string][]models.MemberUser{},
+		cycleIssues:  map[string][]string{},
+		moduleIssues: map[string][]string{},
+		worklogIssue: map[string]string{},
+		lastSequence: map[string]int{},
+		uploads:      map[string][]byte{},
+	}
+	s.registerRoutes()
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// SetClock replaces the clock used for timestamps, e.g. to record an issue
+// history spanning several days in a test
+func (s *Server) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+}
+
+// BaseURL returns the base URL to configure clients with
+func (s *Server) BaseURL() string {
+	return s.URL + apiPrefix
+}
+
+// route is a single endpoint of the fake API
+type route struct {
+	method  string
+	pattern []string
+	handler func(w http.ResponseWriter, r *http.Request, p params)
+}
+
+// params holds the path parameters of a matched route
+type params map[string]string
+
+// handle registers an endpoint. Pattern segments starting with ':' are parameters.
+func (s *Server) handle(method string, pattern string, handler func(w http.ResponseWriter, r *http.Request, p params)) {
+	s.routes = append(s.routes, route{
+		method:  method,
+		pattern: strings.Split(strings.Trim(pattern, "/"), "/"),
+		handler: handler,
+	})
+}
+
+// match reports whether the path segments match the route pattern
+func (rt route) match(segments []string) (params, bool) {
+	if len(segments) != len(rt.pattern) {
+		return nil, false
+	}
+	p := params{}
+	for i, part := range rt.pattern {
+		if strings.HasPrefix(part, ":") {
+			p[part[1:]] = segments[i]
+		} else if part != segments[i] {
+			return nil, false
+		}
+	}
+	return p, true
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path
+	if strings.HasPrefix(path, "/_uploads/") {
+		s.handleUpload(w, r)
+		return
+	}
+	if !strings.HasPrefix(path, apiPrefix+"/") {
+		writeError(w, http.StatusNotFound, "Page not found.")
+		return
+	}
+	if s.APIKey != "" && r.Header.Get("X-API-Key") != s.APIKey {
+		writeError(w, http.StatusUnauthorized, "Invalid API token.")
+		return
+	}
+	if !strings.HasSuffix(path, "/") {
+		// Plane 的接口都以斜杠结尾
+		writeError(w, http.StatusNotFound, "Page not found.")
+		return
+	}
+
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(path, apiPrefix), "/"), "/")
+	pathMatched := false
+	for _, rt := range s.routes {
+		p, ok := rt.match(segments)
+		if !ok {
+			continue
+		}
+		pathMatched = true
+		if rt.method != r.Method {
+			continue
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		rt.handler(w, r, p)
+		return
+	}
+
+	if pathMatched {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method \"%s\" not allowed.", r.Method))
+		return
+	}
+	writeError(w, http.StatusNotFound, "Page not found.")
+}
+
+// writeJSON writes v as a JSON response with the given status code
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error response in the format of the Plane API
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, models.ErrorResponse{Error: message})
+}
+
+// writeNotFound writes the 404 response Plane returns for unknown resources
+func writeNotFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "The requested resource does not exist.")
+}
+
+// writePage writes one page of items, paginated with Plane's cursor format
+// "<per_page>:<page>:0"
+func writePage[T any](s *Server, w http.ResponseWriter, r *http.Request, items []T) {
+	perPage := s.PerPage
+	if v := r.URL.Query().Get("per_page"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			writeError(w, http.StatusBadRequest, "Invalid per_page parameter.")
+			return
+		}
+		perPage = n
+	}
+
+	page := 0
+	if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+		parts := strings.Split(cursor, ":")
+		if len(parts) != 3 {
+			writeError(w, http.StatusBadRequest, "Invalid cursor parameter.")
+			return
+		}
+		n, err := strconv.Atoi(parts[1])
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "Invalid cursor parameter.")
+			return
+		}
+		page = n
+	}
+
+	start := page * perPage
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + perPage
+	if end > len(items) {
+		end = len(items)
+	}
+
+	totalPages := (len(items) + perPage - 1) / perPage
+	results := items[start:end]
+	if results == nil {
+		results = []T{}
+	}
+	writeJSON(w, http.StatusOK, models.PaginatedResponse[T]{
+		TotalCount:      len(items),
+		NextCursor:      fmt.Sprintf("%d:%d:0", perPage, page+1),
+		PrevCursor:      fmt.Sprintf("%d:%d:0", perPage, maxInt(page-1, 0)),
+		NextPageResults: end < len(items),
+		PrevPageResults: page > 0,
+		Count:           len(results),
+		TotalPages:      totalPages,
+		TotalResults:    len(items),
+		Results:         results,
+	})
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// newID returns a random UUID
+func newID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,79 @@
+package planetest_test
+
+import (
+	"testing"
+
+	"github.com/GeekWorkCode/plane-api-go/api"
+	"github.com/GeekWorkCode/plane-api-go/client"
+	"github.com/GeekWorkCode/plane-api-go/models"
+	"github.com/GeekWorkCode/plane-api-go/planetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newClient(srv *planetest.Server, apiKey string) *client.Client {
+	c := client.NewClient(apiKey)
+	c.SetBaseURL(srv.BaseURL())
+	return c
+}
+
+// TestServerAuth tests that requests without a valid API key are rejected
+// 测试没有有效 API 密钥的请求会被拒绝
+func TestServerAuth(t *testing.T) {
+	srv := planetest.NewServer()
+	defer srv.Close()
+	fx := srv.Seed()
+
+	_, err := api.NewProjectsService(newClient(srv, "wrong-key")).List(fx.WorkspaceSlug)
+	assert.True(t, client.IsUnauthorized(err))
+
+	_, err = api.NewProjectsService(newClient(srv, srv.APIKey)).List(fx.WorkspaceSlug)
+	assert.NoError(t, err)
+}
+
+// TestServerNotFound tests the 404 response for unknown resources
+// 测试未知资源返回 404
+func TestServerNotFound(t *testing.T) {
+	srv := planetest.NewServer()
+	defer srv.Close()
+	fx := srv.Seed()
+
+	s := api.NewIssuesService(newClient(srv, srv.APIKey))
+	_, err := s.Get(fx.WorkspaceSlug, fx.ProjectID, "missing")
+	assert.True(t, client.IsNotFound(err))
+
+	_, err = s.Get(fx.WorkspaceSlug, "missing", fx.IssueID)
+	assert.True(t, client.IsNotFound(err))
+}
+
+// TestServerPagination tests that list endpoints are cursor paginated
+// 测试列表接口使用游标分页
+func TestServerPagination(t *testing.T) {
+	srv := planetest.NewServer()
+	defer srv.Close()
+	fx := srv.Seed()
+	for _, name := range []string{"Second", "Third"} {
+		srv.AddIssue(fx.WorkspaceSlug, fx.ProjectID, models.Issue{Name: name})
+	}
+
+	s := api.NewIssuesService(newClient(srv, srv.APIKey))
+	page, err := s.ListPage(fx.WorkspaceSlug, fx.ProjectID, &api.IssueListOptions{ListOptions: api.ListOptions{PerPage: 2}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, page.Results, 2)
+	assert.Equal(t, 3, page.TotalCount)
+	assert.True(t, page.HasNextPage())
+
+	page, err = s.ListPage(fx.WorkspaceSlug, fx.ProjectID, &api.IssueListOptions{ListOptions: api.ListOptions{PerPage: 2, Cursor: page.NextCursor}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, page.Results, 1)
+	assert.False(t, page.HasNextPage())
+
+	issues, err := s.List(fx.WorkspaceSlug, fx.ProjectID)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, issues, 3)
+}
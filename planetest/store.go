@@ -0,0 +1,109 @@
+package planetest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// table stores resources by ID in insertion order
+type table[T any] struct {
+	items map[string]*T
+	order []string
+}
+
+func newTable[T any]() *table[T] {
+	return &table[T]{items: map[string]*T{}}
+}
+
+func (t *table[T]) get(id string) (*T, bool) {
+	item, ok := t.items[id]
+	return item, ok
+}
+
+func (t *table[T]) put(id string, item *T) {
+	if _, ok := t.items[id]; !ok {
+		t.order = append(t.order, id)
+	}
+	t.items[id] = item
+}
+
+func (t *table[T]) delete(id string) bool {
+	if _, ok := t.items[id]; !ok {
+		return false
+	}
+	delete(t.items, id)
+	for i, existing := range t.order {
+		if existing == id {
+			t.order = append(t.order[:i], t.order[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// filter returns copies of the items matching keep, in insertion order
+func (t *table[T]) filter(keep func(*T) bool) []T {
+	results := []T{}
+	for _, id := range t.order {
+		if item := t.items[id]; keep(item) {
+			results = append(results, *item)
+		}
+	}
+	return results
+}
+
+// readBody decodes the JSON request body into a map of raw fields
+func readBody(r *http.Request) (map[string]json.RawMessage, error) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]json.RawMessage{}
+	if len(data) == 0 {
+		return fields, nil
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, errors.New("JSON parse error")
+	}
+	return fields, nil
+}
+
+// apply overlays the request fields onto v, keeping fields that were not sent
+func apply(v interface{}, fields map[string]json.RawMessage) error {
+	current, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	merged := map[string]json.RawMessage{}
+	if err := json.Unmarshal(current, &merged); err != nil {
+		return err
+	}
+	for key, value := range fields {
+		merged[key] = value
+	}
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// stringField returns the string value of a request field
+func stringField(fields map[string]json.RawMessage, key string) string {
+	var s string
+	if raw, ok := fields[key]; ok {
+		json.Unmarshal(raw, &s)
+	}
+	return s
+}
+
+// stringsField returns the string slice value of a request field
+func stringsField(fields map[string]json.RawMessage, key string) []string {
+	var s []string
+	if raw, ok := fields[key]; ok {
+		json.Unmarshal(raw, &s)
+	}
+	return s
+}